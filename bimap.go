@@ -0,0 +1,135 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Returned by BiMap.Put when the value is already associated to another key
+var ErrBiMapValueExists = errors.New("BiMap value already associated to another key")
+
+// Bidirectional map, both the keys and the values are unique
+// so a value can be looked up by key and a key by value (See Inverse()).
+// Keys and values must both be comparable (see Map)
+type BiMap struct {
+	// key -> value
+	forward *Map
+	// value -> key
+	backward *Map
+}
+
+// Initialize a new empty bimap
+func NewBiMap() *BiMap {
+	return &BiMap{forward: NewMap(), backward: NewMap()}
+}
+
+// Clear (empty) the bimap (and therefore its inverse)
+// Return the bimap pointer to allow method chaining.
+func (b *BiMap) Clear() *BiMap {
+	b.forward.Clear()
+	b.backward.Clear()
+	return b
+}
+
+// Does the bimap contain the given key
+func (b *BiMap) ContainsKey(key interface{}) bool {
+	return b.forward.ContainsKey(key)
+}
+
+// Does the bimap contain the given value
+func (b *BiMap) ContainsValue(val interface{}) bool {
+	return b.backward.ContainsKey(val)
+}
+
+// Apply the function to all the entries (in insertion order)
+// If the function returns true (stop), iteration will stop
+func (b *BiMap) Each(f func(key, val interface{}) (stop bool)) {
+	b.forward.Each(f)
+}
+
+// Same as Put() but if the value is already associated to another key,
+// that entry is removed first rather than returning an error.
+// Return the bimap pointer to allow method chaining.
+func (b *BiMap) ForcePut(key, val interface{}) *BiMap {
+	if other, found := b.backward.get(val); found && other != key {
+		b.forward.Remove(other)
+		b.backward.Remove(val)
+	}
+	b.put(key, val)
+	return b
+}
+
+// Set value of ptr to the value associated with key
+// Return false (and leave ptr untouched) if the key is not in the bimap
+func (b *BiMap) Get(key interface{}, ptr interface{}) (found bool) {
+	return b.forward.Get(key, ptr)
+}
+
+// Returns the inverse view of this bimap (values -> keys)
+// The view is live, it's backed by this bimap so changes to either are
+// reflected in the other.
+func (b *BiMap) Inverse() *BiMap {
+	return &BiMap{forward: b.backward, backward: b.forward}
+}
+
+// Is this bimap empty
+func (b *BiMap) IsEmpty() bool {
+	return b.forward.IsEmpty()
+}
+
+// Returns a new Slice of all the keys (in insertion order)
+func (b *BiMap) Keys() *Slice {
+	return b.forward.Keys()
+}
+
+// Number of entries in the bimap
+func (b *BiMap) Len() int {
+	return b.forward.Len()
+}
+
+// Associate the value to the key, replacing the key previous value (if any)
+// Returns ErrBiMapValueExists (and leave the bimap unchanged) if the value
+// is already associated with another key, see ForcePut()
+func (b *BiMap) Put(key, val interface{}) error {
+	if other, found := b.backward.get(val); found && other != key {
+		return ErrBiMapValueExists
+	}
+	b.put(key, val)
+	return nil
+}
+
+// Remove the entry with the given key (if any)
+// Return the bimap pointer to allow method chaining.
+func (b *BiMap) Remove(key interface{}) *BiMap {
+	if val, found := b.forward.get(key); found {
+		b.forward.Remove(key)
+		b.backward.Remove(val)
+	}
+	return b
+}
+
+// Empty the bimap and its inverse (impl Clearable)
+func (b *BiMap) Reset() {
+	b.Clear()
+}
+
+// impl String interface
+func (b *BiMap) String() string {
+	return fmt.Sprintf("BiMap[%d] [%s]", b.Len(), b.forward.Join(" "))
+}
+
+// Returns a new Slice of all the values (in insertion order of their keys)
+func (b *BiMap) Vals() *Slice {
+	return b.forward.Vals()
+}
+
+// Associate key and val, the value must not be associated to another key
+func (b *BiMap) put(key, val interface{}) {
+	if old, found := b.forward.get(key); found {
+		b.backward.Remove(old)
+	}
+	b.forward.Set(key, val)
+	b.backward.Set(val, key)
+}
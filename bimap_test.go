@@ -0,0 +1,69 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestBiMap(t *testing.T) {
+	var name string
+	var id int
+
+	convey.Convey("Put & Get", t, func() {
+		b := testBiMap()
+		convey.So(b.Get(2, &name), convey.ShouldBeTrue)
+		convey.So(name, convey.ShouldEqual, "two")
+		convey.So(b.Inverse().Get("three", &id), convey.ShouldBeTrue)
+		convey.So(id, convey.ShouldEqual, 3)
+		convey.So(b.Get(9, &name), convey.ShouldBeFalse)
+		convey.So(b.ContainsKey(1), convey.ShouldBeTrue)
+		convey.So(b.ContainsValue("one"), convey.ShouldBeTrue)
+		convey.So(b.ContainsValue(1), convey.ShouldBeFalse)
+		convey.So(b.String(), convey.ShouldEqual, "BiMap[3] [1:one 2:two 3:three]")
+	})
+
+	convey.Convey("Conflicts", t, func() {
+		b := testBiMap()
+		convey.So(b.Put(4, "one"), convey.ShouldEqual, ErrBiMapValueExists)
+		convey.So(b.Len(), convey.ShouldEqual, 3)
+		convey.So(b.Put(1, "one"), convey.ShouldBeNil) // same entry, no conflict
+		// Replacing the value of an existing key frees the old value
+		convey.So(b.Put(1, "uno"), convey.ShouldBeNil)
+		convey.So(b.ContainsValue("one"), convey.ShouldBeFalse)
+		convey.So(b.Put(5, "one"), convey.ShouldBeNil)
+		// ForcePut steals the value from the other key
+		b.ForcePut(6, "two")
+		convey.So(b.ContainsKey(2), convey.ShouldBeFalse)
+		convey.So(b.Inverse().Get("two", &id), convey.ShouldBeTrue)
+		convey.So(id, convey.ShouldEqual, 6)
+		convey.So(b.Keys().Join(","), convey.ShouldEqual, "1,3,5,6")
+		convey.So(b.Vals().Join(","), convey.ShouldEqual, "uno,three,one,two")
+	})
+
+	convey.Convey("Inverse view", t, func() {
+		b := testBiMap()
+		inv := b.Inverse()
+		inv.Put("four", 4)
+		convey.So(b.Get(4, &name), convey.ShouldBeTrue)
+		convey.So(name, convey.ShouldEqual, "four")
+		b.Remove(1)
+		convey.So(inv.ContainsKey("one"), convey.ShouldBeFalse)
+		convey.So(inv.Len(), convey.ShouldEqual, 3)
+		convey.So(inv.Put("two", 3), convey.ShouldEqual, ErrBiMapValueExists)
+		convey.So(inv.Inverse().Keys().Join(","), convey.ShouldEqual, "2,3,4")
+		inv.Clear()
+		convey.So(b.IsEmpty(), convey.ShouldBeTrue)
+	})
+}
+
+// #################### TESTS DATA ############################################
+
+func testBiMap() *BiMap {
+	b := NewBiMap()
+	b.Put(1, "one")
+	b.Put(2, "two")
+	b.Put(3, "three")
+	return b
+}
@@ -0,0 +1,333 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"bytes"
+	"fmt"
+	"math/bits"
+)
+
+// Dense set of non negative integers, backed by a growable array of 64 bits words
+// Memory usage is proportional to the largest value, so for sparse sets use
+// SparseBitSet instead.
+// Methods taking indexes panic on negative indexes.
+// Ranges are inclusive (from and to), same as Slice ranges.
+type BitSet struct {
+	words []uint64
+}
+
+// Initialize a new empty bitset
+func NewBitSet() *BitSet {
+	return &BitSet{}
+}
+
+// Initialize a new bitset with the (int) values of the slice set
+func NewBitSetFromSlice(s *Slice) *BitSet {
+	b := NewBitSet()
+	for _, e := range s.slice {
+		b.Set(e.(int))
+	}
+	return b
+}
+
+// Keep only the bits also set in other (in place intersection)
+// Return the bitset pointer to allow method chaining.
+func (b *BitSet) And(other *BitSet) *BitSet {
+	for i := range b.words {
+		if i < len(other.words) {
+			b.words[i] &= other.words[i]
+		} else {
+			b.words[i] = 0
+		}
+	}
+	return b
+}
+
+// Clear the bits also set in other (in place difference)
+// Return the bitset pointer to allow method chaining.
+func (b *BitSet) AndNot(other *BitSet) *BitSet {
+	for i := 0; i < len(b.words) && i < len(other.words); i++ {
+		b.words[i] &^= other.words[i]
+	}
+	return b
+}
+
+// Number of bits set (population count)
+func (b *BitSet) Cardinality() int {
+	count := 0
+	for _, w := range b.words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+// Clear (unset) the bit at index i
+// Return the bitset pointer to allow method chaining.
+func (b *BitSet) Clear(i int) *BitSet {
+	checkBitIndex(i)
+	if w := i / 64; w < len(b.words) {
+		b.words[w] &^= 1 << uint(i%64)
+	}
+	return b
+}
+
+// Clear all the bits
+// Return the bitset pointer to allow method chaining.
+func (b *BitSet) ClearAll() *BitSet {
+	b.words = nil
+	return b
+}
+
+// Clear all the bits within the given range (from and to are both inclusive)
+// Return the bitset pointer to allow method chaining.
+func (b *BitSet) ClearRange(from, to int) *BitSet {
+	checkBitRange(from, to)
+	b.eachRangeWord(from, to, func(w int, mask uint64) {
+		b.words[w] &^= mask
+	})
+	return b
+}
+
+// Create and return a clone of this bitset
+func (b *BitSet) Clone() *BitSet {
+	clone := NewBitSet()
+	clone.words = append(clone.words, b.words...)
+	return clone
+}
+
+// Is the bit at the given (int) index set, false if elem is not a positive int
+// (the elements of a bitset being the indexes of its bits set, See Each())
+func (b *BitSet) Contains(elem interface{}) bool {
+	i, ok := elem.(int)
+	return ok && i >= 0 && b.Test(i)
+}
+
+// Apply the function to all the bits set, in increasing order
+// The function is given the position (0 based) of the bit amongst the set bits
+// and the bit index (as an int).
+// If the function returns true (stop), iteration will stop
+func (b *BitSet) Each(f func(int, interface{}) (stop bool)) {
+	pos := 0
+	for i := b.NextSetBit(0); i >= 0; i = b.NextSetBit(i + 1) {
+		if f(pos, i) {
+			return
+		}
+		pos++
+	}
+}
+
+// Is this bitset equal to another one (same bits set)
+func (b *BitSet) Equals(other *BitSet) bool {
+	long, short := b.words, other.words
+	if len(long) < len(short) {
+		long, short = short, long
+	}
+	for i, w := range long {
+		if i < len(short) {
+			if w != short[i] {
+				return false
+			}
+		} else if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Flip (toggle) the bit at index i
+// Return the bitset pointer to allow method chaining.
+func (b *BitSet) Flip(i int) *BitSet {
+	checkBitIndex(i)
+	b.grow(i)
+	b.words[i/64] ^= 1 << uint(i%64)
+	return b
+}
+
+// Flip (toggle) all the bits within the given range (from and to are both inclusive)
+// Return the bitset pointer to allow method chaining.
+func (b *BitSet) FlipRange(from, to int) *BitSet {
+	checkBitRange(from, to)
+	b.grow(to)
+	b.eachRangeWord(from, to, func(w int, mask uint64) {
+		b.words[w] ^= mask
+	})
+	return b
+}
+
+// Is no bit set
+func (b *BitSet) IsEmpty() bool {
+	for _, w := range b.words {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Number of bits set (same as Cardinality)
+func (b *BitSet) Len() int {
+	return b.Cardinality()
+}
+
+// Index of the first clear bit at or after from
+// There is always one since the bitset is unbounded.
+func (b *BitSet) NextClearBit(from int) int {
+	checkBitIndex(from)
+	w := from / 64
+	if w >= len(b.words) {
+		return from
+	}
+	word := ^b.words[w] & (^uint64(0) << uint(from%64))
+	for {
+		if word != 0 {
+			return w*64 + bits.TrailingZeros64(word)
+		}
+		w++
+		if w == len(b.words) {
+			return w * 64
+		}
+		word = ^b.words[w]
+	}
+}
+
+// Index of the first set bit at or after from
+// Returns -1 if there are none.
+func (b *BitSet) NextSetBit(from int) int {
+	checkBitIndex(from)
+	w := from / 64
+	if w >= len(b.words) {
+		return -1
+	}
+	word := b.words[w] & (^uint64(0) << uint(from%64))
+	for {
+		if word != 0 {
+			return w*64 + bits.TrailingZeros64(word)
+		}
+		w++
+		if w == len(b.words) {
+			return -1
+		}
+		word = b.words[w]
+	}
+}
+
+// Set the bits that are set in other (in place union)
+// Return the bitset pointer to allow method chaining.
+func (b *BitSet) Or(other *BitSet) *BitSet {
+	for len(b.words) < len(other.words) {
+		b.words = append(b.words, 0)
+	}
+	for i, w := range other.words {
+		b.words[i] |= w
+	}
+	return b
+}
+
+// Unset all the bits, releasing the words (impl Clearable)
+func (b *BitSet) Reset() {
+	b.ClearAll()
+}
+
+// Set the bit at index i
+// Return the bitset pointer to allow method chaining.
+func (b *BitSet) Set(i int) *BitSet {
+	checkBitIndex(i)
+	b.grow(i)
+	b.words[i/64] |= 1 << uint(i%64)
+	return b
+}
+
+// Set all the bits within the given range (from and to are both inclusive)
+// Return the bitset pointer to allow method chaining.
+func (b *BitSet) SetRange(from, to int) *BitSet {
+	checkBitRange(from, to)
+	b.grow(to)
+	b.eachRangeWord(from, to, func(w int, mask uint64) {
+		b.words[w] |= mask
+	})
+	return b
+}
+
+// impl String interface
+func (b *BitSet) String() string {
+	var buf bytes.Buffer
+	b.Each(func(pos int, i interface{}) bool {
+		if pos != 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString(fmt.Sprintf("%d", i))
+		return false
+	})
+	return fmt.Sprintf("BitSet[%d] {%s}", b.Cardinality(), buf.String())
+}
+
+// Is the bit at index i set
+func (b *BitSet) Test(i int) bool {
+	checkBitIndex(i)
+	w := i / 64
+	return w < len(b.words) && b.words[w]&(1<<uint(i%64)) != 0
+}
+
+// Returns a new Slice of the (int) indexes of the bits set, in increasing order
+func (b *BitSet) ToSlice() *Slice {
+	s := NewSlice()
+	for i := b.NextSetBit(0); i >= 0; i = b.NextSetBit(i + 1) {
+		s.slice = append(s.slice, i)
+	}
+	return s
+}
+
+// Only keep the bits set in either but not both bitsets (in place symmetric difference)
+// Return the bitset pointer to allow method chaining.
+func (b *BitSet) Xor(other *BitSet) *BitSet {
+	for len(b.words) < len(other.words) {
+		b.words = append(b.words, 0)
+	}
+	for i, w := range other.words {
+		b.words[i] ^= w
+	}
+	return b
+}
+
+// Call f with each word index within the range and the mask of the bits of
+// that word that are in the range. Words past the current size are skipped.
+func (b *BitSet) eachRangeWord(from, to int, f func(w int, mask uint64)) {
+	last := to / 64
+	if last >= len(b.words) {
+		last = len(b.words) - 1
+	}
+	for w := from / 64; w <= last; w++ {
+		mask := ^uint64(0)
+		if w == from/64 {
+			mask &= ^uint64(0) << uint(from%64)
+		}
+		if w == to/64 {
+			mask &= ^uint64(0) >> uint(63-to%64)
+		}
+		f(w, mask)
+	}
+}
+
+// Make sure the words can hold bit i
+func (b *BitSet) grow(i int) {
+	if w := i / 64; w >= len(b.words) {
+		b.words = append(b.words, make([]uint64, w+1-len(b.words))...)
+	}
+}
+
+// Panic if i is not a valid bit index
+func checkBitIndex(i int) {
+	if i < 0 {
+		panic(fmt.Sprintf("Invalid bit index: %d", i))
+	}
+}
+
+// Panic if from-to is not a valid bit range
+func checkBitRange(from, to int) {
+	checkBitIndex(from)
+	checkBitIndex(to)
+	if from > to {
+		panic(fmt.Sprintf("Invalid bit range: %d-%d", from, to))
+	}
+}
@@ -0,0 +1,159 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"github.com/smartystreets/goconvey/convey"
+	"math/rand"
+	"testing"
+)
+
+func TestBitSet(t *testing.T) {
+
+	convey.Convey("Set, Clear, Flip, Test", t, func() {
+		b := NewBitSet().Set(1).Set(64).Set(200)
+		convey.So(b.Test(64), convey.ShouldBeTrue)
+		convey.So(b.Test(63), convey.ShouldBeFalse)
+		convey.So(b.Test(5000), convey.ShouldBeFalse)
+		convey.So(b.Cardinality(), convey.ShouldEqual, 3)
+		b.Clear(64).Clear(9999).Flip(1).Flip(2)
+		convey.So(b.String(), convey.ShouldEqual, "BitSet[2] {2 200}")
+		convey.So(func() { b.Set(-1) }, convey.ShouldPanic)
+		b.ClearAll()
+		convey.So(b.IsEmpty(), convey.ShouldBeTrue)
+	})
+
+	convey.Convey("Ranges", t, func() {
+		b := NewBitSet().SetRange(60, 130)
+		convey.So(b.Cardinality(), convey.ShouldEqual, 71)
+		convey.So(b.NextSetBit(0), convey.ShouldEqual, 60)
+		convey.So(b.NextClearBit(60), convey.ShouldEqual, 131)
+		b.ClearRange(62, 127)
+		convey.So(b.ToSlice().Join(","), convey.ShouldEqual, "60,61,128,129,130")
+		b.FlipRange(61, 129)
+		convey.So(b.Cardinality(), convey.ShouldEqual, 68)
+		convey.So(b.Test(61), convey.ShouldBeFalse)
+		convey.So(b.Test(100), convey.ShouldBeTrue)
+		convey.So(func() { b.SetRange(5, 2) }, convey.ShouldPanic)
+	})
+
+	convey.Convey("Iteration", t, func() {
+		b := NewBitSetFromSlice(NewSlice().AppendAll(3, 70, 128, 5))
+		convey.So(b.NextSetBit(4), convey.ShouldEqual, 5)
+		convey.So(b.NextSetBit(71), convey.ShouldEqual, 128)
+		convey.So(b.NextSetBit(129), convey.ShouldEqual, -1)
+		convey.So(b.NextClearBit(3), convey.ShouldEqual, 4)
+		convey.So(b.NextClearBit(500), convey.ShouldEqual, 500)
+		a := ""
+		b.Each(func(pos int, i interface{}) bool {
+			a += NewSlice().AppendAll(pos, i).Join(":") + " "
+			return i == 70
+		})
+		convey.So(a, convey.ShouldEqual, "0:3 1:5 2:70 ")
+	})
+
+	convey.Convey("Logic", t, func() {
+		a := NewBitSet().SetRange(0, 9)
+		b := NewBitSet().SetRange(5, 14).Set(300)
+		convey.So(a.Clone().And(b).String(), convey.ShouldEqual, "BitSet[5] {5 6 7 8 9}")
+		convey.So(a.Clone().Or(b).Cardinality(), convey.ShouldEqual, 16)
+		convey.So(a.Clone().Xor(b).String(), convey.ShouldEqual, "BitSet[11] {0 1 2 3 4 10 11 12 13 14 300}")
+		convey.So(a.Clone().AndNot(b).String(), convey.ShouldEqual, "BitSet[5] {0 1 2 3 4}")
+		convey.So(b.Clone().And(a).Equals(a.Clone().And(b)), convey.ShouldBeTrue)
+		convey.So(a.Equals(b), convey.ShouldBeFalse)
+	})
+}
+
+func TestSparseBitSet(t *testing.T) {
+
+	convey.Convey("Set, Clear, Flip, Test", t, func() {
+		b := NewSparseBitSet().Set(1).Set(70000).Set(1 << 40)
+		convey.So(b.Test(70000), convey.ShouldBeTrue)
+		convey.So(b.Test(1<<40), convey.ShouldBeTrue)
+		convey.So(b.Test(70001), convey.ShouldBeFalse)
+		convey.So(b.Cardinality(), convey.ShouldEqual, 3)
+		b.Clear(70000).Clear(5).Flip(1).Flip(2)
+		convey.So(b.String(), convey.ShouldEqual, "SparseBitSet[2] {2 1099511627776}")
+		convey.So(len(b.containers), convey.ShouldEqual, 2)
+		b.ClearAll()
+		convey.So(b.IsEmpty(), convey.ShouldBeTrue)
+	})
+
+	convey.Convey("Dense chunks", t, func() {
+		b := NewSparseBitSet().SetRange(0, 9999)
+		convey.So(b.containers[0].bitmap, convey.ShouldNotBeNil)
+		convey.So(b.Cardinality(), convey.ShouldEqual, 10000)
+		convey.So(b.NextClearBit(0), convey.ShouldEqual, 10000)
+		b.ClearRange(10, 9990)
+		convey.So(b.containers[0].bitmap, convey.ShouldBeNil)
+		convey.So(b.ToSlice().Len(), convey.ShouldEqual, 19)
+		convey.So(b.NextSetBit(10), convey.ShouldEqual, 9991)
+		b.FlipRange(0, 19)
+		convey.So(b.NextSetBit(0), convey.ShouldEqual, 10)
+	})
+
+	convey.Convey("Logic", t, func() {
+		a := NewSparseBitSet().SetRange(0, 4999).Set(100000)
+		b := NewSparseBitSet().SetRange(4000, 8999).Set(200000)
+		convey.So(a.Clone().And(b).Cardinality(), convey.ShouldEqual, 1000)
+		convey.So(a.Clone().Or(b).Cardinality(), convey.ShouldEqual, 9002)
+		convey.So(a.Clone().Xor(b).Cardinality(), convey.ShouldEqual, 8002)
+		andNot := a.Clone().AndNot(b)
+		convey.So(andNot.Cardinality(), convey.ShouldEqual, 4001)
+		convey.So(andNot.Test(100000), convey.ShouldBeTrue)
+		convey.So(andNot.Test(4000), convey.ShouldBeFalse)
+		// Same content regardless of the internal representation
+		dense := NewSparseBitSet().SetRange(0, 5000).ClearRange(3, 5000)
+		convey.So(dense.Equals(NewSparseBitSet().SetRange(0, 2)), convey.ShouldBeTrue)
+		convey.So(dense.Equals(a), convey.ShouldBeFalse)
+	})
+
+	convey.Convey("Same as BitSet", t, func() {
+		s := NewSlice().AppendAll(5, 64, 65, 1000, 65536, 65537, 300000)
+		dense := NewBitSetFromSlice(s)
+		sparse := NewSparseBitSetFromSlice(s)
+		convey.So(sparse.ToSlice().Join(","), convey.ShouldEqual, dense.ToSlice().Join(","))
+		for _, i := range []int{0, 5, 6, 65, 65535, 65536, 299999} {
+			convey.So(sparse.NextSetBit(i), convey.ShouldEqual, dense.NextSetBit(i))
+			convey.So(sparse.NextClearBit(i), convey.ShouldEqual, dense.NextClearBit(i))
+		}
+	})
+
+	convey.Convey("Large ranges", t, func() {
+		// done a chunk at a time, not a bit at a time
+		b := NewSparseBitSet().SetRange(1, 1<<26).Set(1 << 40)
+		convey.So(b.Cardinality(), convey.ShouldEqual, 1<<26+1)
+		convey.So(b.NextClearBit(1), convey.ShouldEqual, 1<<26+1)
+		b.FlipRange(0, 1<<26+1)
+		convey.So(b.ToSlice().Join(","), convey.ShouldEqual, "0,67108865,1099511627776")
+		b.SetRange(100, 1<<25).ClearRange(0, 1<<50)
+		convey.So(b.IsEmpty(), convey.ShouldBeTrue)
+	})
+
+	convey.Convey("Random ranges, same as BitSet", t, func() {
+		rng := rand.New(rand.NewSource(4))
+		dense, sparse := NewBitSet(), NewSparseBitSet()
+		for i := 0; i != 300; i++ {
+			from := rng.Intn(300000)
+			to := from + rng.Intn(100000)
+			if rng.Intn(4) == 0 {
+				to = from + rng.Intn(100) // stay within a chunk
+			}
+			switch rng.Intn(3) {
+			case 0:
+				dense.SetRange(from, to)
+				sparse.SetRange(from, to)
+			case 1:
+				dense.ClearRange(from, to)
+				sparse.ClearRange(from, to)
+			default:
+				dense.FlipRange(from, to)
+				sparse.FlipRange(from, to)
+			}
+			convey.So(sparse.Cardinality(), convey.ShouldEqual, dense.Cardinality())
+			convey.So(sparse.NextClearBit(from), convey.ShouldEqual, dense.NextClearBit(from))
+			convey.So(sparse.NextSetBit(from), convey.ShouldEqual, dense.NextSetBit(from))
+		}
+		convey.So(sparse.ToSlice().Join(","), convey.ShouldEqual, dense.ToSlice().Join(","))
+	})
+}
@@ -0,0 +1,197 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Elements implementing Cloner are copied by DeepClone / DeepCopy using their
+// Clone method rather than by reflection.
+type Cloner interface {
+	Clone() interface{}
+}
+
+// Create and return a deep copy of this slice: the elements are copied
+// recursively (See DeepCopy())
+func (s *Slice) DeepClone() *Slice {
+	return newDeepCopier().copy(s).(*Slice)
+}
+
+// Create and return a deep copy of this map: the values are copied recursively
+// (See DeepCopy()), the keys are kept as is.
+func (m *Map) DeepClone() *Map {
+	return newDeepCopier().copy(m).(*Map)
+}
+
+// Returns a deep copy of val
+// - Values implementing Cloner are copied by calling Clone()
+// - *Slice and *Map are copied with their elements (not the Map keys) deep copied
+// - Go pointers, slices, arrays, maps (values), interfaces and structs are copied recursively
+// - Everything else (numbers, strings, funcs, channels ...) is kept as is
+// Values referenced several times are copied once, so cycles and shared values
+// are preserved in the copy.
+// Note: Unexported struct fields can't be set by reflection, so they are shallow copied.
+func DeepCopy(val interface{}) interface{} {
+	return newDeepCopier().copy(val)
+}
+
+var clonerType = reflect.TypeOf((*Cloner)(nil)).Elem()
+
+// Copies already made, by identity of the original
+type deepCopier struct {
+	copies map[deepCopyKey]reflect.Value
+}
+
+type deepCopyKey struct {
+	typ reflect.Type
+	ptr uintptr
+	len int
+}
+
+func newDeepCopier() *deepCopier {
+	return &deepCopier{copies: map[deepCopyKey]reflect.Value{}}
+}
+
+func (c *deepCopier) copy(val interface{}) interface{} {
+	if val == nil {
+		return nil
+	}
+	return c.copyValue(reflect.ValueOf(val)).Interface()
+}
+
+func (c *deepCopier) copyValue(v reflect.Value) reflect.Value {
+	if copied, ok := c.special(v); ok {
+		return copied
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		key := deepCopyKey{v.Type(), v.Pointer(), 0}
+		if copied, ok := c.copies[key]; ok {
+			return copied
+		}
+		copied := reflect.New(v.Type().Elem())
+		c.copies[key] = copied
+		copied.Elem().Set(c.copyValue(v.Elem()))
+		return copied
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		key := deepCopyKey{v.Type(), v.Pointer(), v.Len()}
+		if copied, ok := c.copies[key]; ok {
+			return copied
+		}
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		c.copies[key] = copied
+		for i := 0; i != v.Len(); i++ {
+			copied.Index(i).Set(c.copyValue(v.Index(i)))
+		}
+		return copied
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		key := deepCopyKey{v.Type(), v.Pointer(), 0}
+		if copied, ok := c.copies[key]; ok {
+			return copied
+		}
+		copied := reflect.MakeMapWithSize(v.Type(), v.Len())
+		c.copies[key] = copied
+		iter := v.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), c.copyValue(iter.Value()))
+		}
+		return copied
+	case reflect.Array:
+		copied := reflect.New(v.Type()).Elem()
+		for i := 0; i != v.Len(); i++ {
+			copied.Index(i).Set(c.copyValue(v.Index(i)))
+		}
+		return copied
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(c.copyValue(v.Elem()))
+		return copied
+	case reflect.Struct:
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(v)
+		for i := 0; i != v.NumField(); i++ {
+			if field := copied.Field(i); field.CanSet() {
+				field.Set(c.copyValue(v.Field(i)))
+			}
+		}
+		return copied
+	}
+	return v
+}
+
+// Copy the values that are not copied by reflection: Cloner, *Slice & *Map
+func (c *deepCopier) special(v reflect.Value) (reflect.Value, bool) {
+	if !v.CanInterface() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return v, false
+	}
+	if v.Kind() == reflect.Ptr && v.Type().Elem().Implements(clonerType) {
+		// Clone has a value receiver (promoted to *T): copy the pointer
+		// reflectively, the element being copied by its Clone method
+		return v, false
+	}
+	switch val := v.Interface().(type) {
+	case Cloner:
+		// A pointer Cloner referenced several times is cloned once
+		var key *deepCopyKey
+		if v.Kind() == reflect.Ptr {
+			key = &deepCopyKey{v.Type(), v.Pointer(), 0}
+			if copied, ok := c.copies[*key]; ok {
+				return copied, true
+			}
+		}
+		clone := val.Clone()
+		copied := reflect.ValueOf(clone)
+		if copied.IsValid() && copied.Kind() == reflect.Ptr && !copied.IsNil() &&
+			!copied.Type().AssignableTo(v.Type()) && copied.Type().Elem().AssignableTo(v.Type()) {
+			copied = copied.Elem() // Clone of a T returned a *T
+		}
+		if !copied.IsValid() || !copied.Type().AssignableTo(v.Type()) {
+			panic(fmt.Sprintf("Clone() of %T returned a %T", val, clone))
+		}
+		if key != nil {
+			c.copies[*key] = copied
+		}
+		return copied, true
+	case *Slice:
+		key := deepCopyKey{v.Type(), v.Pointer(), 0}
+		if copied, ok := c.copies[key]; ok {
+			return copied, true
+		}
+		clone := val.emptyCopy()
+		copied := reflect.ValueOf(clone)
+		c.copies[key] = copied
+		clone.slice = make([]interface{}, len(val.slice))
+		for i, e := range val.slice {
+			clone.slice[i] = c.copy(e)
+		}
+		return copied, true
+	case *Map:
+		key := deepCopyKey{v.Type(), v.Pointer(), 0}
+		if copied, ok := c.copies[key]; ok {
+			return copied, true
+		}
+		clone := NewMap()
+		copied := reflect.ValueOf(clone)
+		c.copies[key] = copied
+		val.Each(func(k, e interface{}) bool {
+			clone.Set(k, c.copy(e))
+			return false
+		})
+		return copied, true
+	}
+	return v, false
+}
@@ -0,0 +1,143 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+
+	convey.Convey("Clone", t, func() {
+		s := testSlice()
+		s.Compare = func(a, b interface{}) int { return naturalCompare(a, b) }
+		s.Hash = DefaultHash
+		s.Subscribe(func(e SliceEvent) {})
+		clone := s.Clone()
+		convey.So(clone.Len(), convey.ShouldEqual, 6)
+		convey.So(clone.Join(","), convey.ShouldEqual, "1,2,3,7,9,15")
+		convey.So(clone.Compare, convey.ShouldNotBeNil)
+		convey.So(clone.Hash, convey.ShouldNotBeNil)
+		clone.Set(0, 9)
+		convey.So(s.Join(","), convey.ShouldEqual, "1,2,3,7,9,15")
+		convey.So(clone.obs, convey.ShouldBeNil)
+		convey.So(NewSlice().Clone().IsEmpty(), convey.ShouldBeTrue)
+	})
+
+	convey.Convey("DeepClone", t, func() {
+		inner := NewSlice().AppendAll(1, 2)
+		m := NewMap().Set("list", []int{1, 2}).Set("slice", inner)
+		user := &testUser{Name: "joe", Tags: []string{"a"}, Attrs: map[string]int{"x": 1}}
+		s := NewSlice().AppendAll(inner, m, user, &testCloner{val: 3}, [2]*int{}, nil, "str")
+		clone := s.DeepClone()
+		convey.So(clone.Len(), convey.ShouldEqual, 7)
+		var innerClone *Slice
+		clone.Get(0, &innerClone)
+		convey.So(innerClone, convey.ShouldNotPointTo, inner)
+		innerClone.Append(3)
+		convey.So(inner.Len(), convey.ShouldEqual, 2)
+		var mClone *Map
+		clone.Get(1, &mClone)
+		var list []int
+		mClone.Get("list", &list)
+		list[0] = 5
+		m.Get("list", &list)
+		convey.So(list[0], convey.ShouldEqual, 1)
+		// shared values are copied once
+		var shared *Slice
+		mClone.Get("slice", &shared)
+		convey.So(shared, convey.ShouldPointTo, innerClone)
+		var userClone *testUser
+		clone.Get(2, &userClone)
+		convey.So(userClone, convey.ShouldResemble, user)
+		userClone.Tags[0] = "b"
+		userClone.Attrs["x"] = 2
+		convey.So(user.Tags[0], convey.ShouldEqual, "a")
+		convey.So(user.Attrs["x"], convey.ShouldEqual, 1)
+		var cloner *testCloner
+		clone.Get(3, &cloner)
+		convey.So(cloner.cloned, convey.ShouldBeTrue)
+		convey.So(m.DeepClone().Keys().Join(","), convey.ShouldEqual, "list,slice")
+	})
+
+	convey.Convey("Value receiver Cloner", t, func() {
+		ptr := &testValCloner{val: 4}
+		s := NewSlice().AppendAll(ptr, testValCloner{val: 5}, ptr)
+		clone := s.DeepClone()
+		var ptrClone, ptrClone2 *testValCloner
+		clone.Get(0, &ptrClone)
+		convey.So(ptrClone, convey.ShouldNotPointTo, ptr)
+		convey.So(*ptrClone, convey.ShouldResemble, testValCloner{val: 4, cloned: true})
+		clone.Get(2, &ptrClone2)
+		convey.So(ptrClone2, convey.ShouldPointTo, ptrClone)
+		var valClone testValCloner
+		clone.Get(1, &valClone)
+		convey.So(valClone, convey.ShouldResemble, testValCloner{val: 5, cloned: true})
+		convey.So(ptr.cloned, convey.ShouldBeFalse)
+	})
+
+	convey.Convey("Shared pointer Cloner", t, func() {
+		shared := &testCloner{val: 6}
+		s := NewSlice().AppendAll(shared, NewSlice().Append(shared), shared)
+		clone := s.DeepClone()
+		var first, last, nested *testCloner
+		var inner *Slice
+		clone.Get(0, &first)
+		clone.Get(2, &last)
+		clone.Get(1, &inner)
+		inner.Get(0, &nested)
+		convey.So(first, convey.ShouldNotPointTo, shared)
+		convey.So(first.cloned, convey.ShouldBeTrue)
+		convey.So(last, convey.ShouldPointTo, first)
+		convey.So(nested, convey.ShouldPointTo, first)
+	})
+
+	convey.Convey("Cycles", t, func() {
+		s := NewSlice()
+		s.Append(s)
+		user := &testUser{Name: "joe"}
+		user.Friend = user
+		s.Append(user)
+		clone := s.DeepClone()
+		var self *Slice
+		clone.Get(0, &self)
+		convey.So(self == clone, convey.ShouldBeTrue)
+		var userClone *testUser
+		clone.Get(1, &userClone)
+		convey.So(userClone, convey.ShouldNotPointTo, user)
+		convey.So(userClone.Friend, convey.ShouldPointTo, userClone)
+		loop := []interface{}{1, nil}
+		loop[1] = loop
+		copied := DeepCopy(loop).([]interface{})
+		convey.So(copied[1].([]interface{})[0], convey.ShouldEqual, 1)
+		convey.So(DeepCopy(nil), convey.ShouldBeNil)
+	})
+}
+
+// #################### TESTS DATA ####
+
+type testUser struct {
+	Name   string
+	Tags   []string
+	Attrs  map[string]int
+	Friend *testUser
+}
+
+type testCloner struct {
+	val    int
+	cloned bool
+}
+
+func (c *testCloner) Clone() interface{} {
+	return &testCloner{val: c.val, cloned: true}
+}
+
+type testValCloner struct {
+	val    int
+	cloned bool
+}
+
+func (c testValCloner) Clone() interface{} {
+	return testValCloner{val: c.val, cloned: true}
+}
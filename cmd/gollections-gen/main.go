@@ -0,0 +1,294 @@
+// History: Oct 18 26 agent Creation
+
+// Command gollections-gen generates type specialized copies of gollections.Slice
+// For example an IntSlice holding ints rather than interface{}, with the same
+// methods but taking and returning ints, so it's free of the reflection and type
+// assertions costs of the "generic" Slice.
+//
+// It can be driven by flags:
+//
+//	gollections-gen -type=int -name=IntSlice
+//
+// Or, when called without flags, by //gollections:gen directives (taking the
+// same flags) found in the go files of the current directory, typically from
+// go generate:
+//
+//	//go:generate gollections-gen
+//	//gollections:gen -type=*User -name=UserSlice -test=&User{1},&User{2},&User{3}
+//
+// The directive flags are separated by spaces, except those nested in parenthesis,
+// brackets, braces or strings (ie: -test=User{1, "a"},User{2, "b"},User{3, "c"}),
+// a value quoted as a whole is unquoted (ie: -import="my/path").
+//
+// Flags:
+//
+//	-type        Element type (required), ie: int, *User, time.Time
+//	-name        Name of the generated type (required), ie: IntSlice
+//	-package     Package of the generated file (default: package of the current directory)
+//	-output      Generated file name (default: <name>_gen.go, lower case)
+//	-import      Comma separated import paths needed by the element type
+//	-comparable  Element type supports ==, used by the default Equals rather than reflect.DeepEqual
+//	-test        Comma separated list of (at least 3, distinct) values of the element type,
+//	             when set a test file (<name>_gen_test.go) is generated as well
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// Directive prefix in go source comments
+const directive = "//gollections:gen"
+
+// Settings of one generated type
+type config struct {
+	Type       string
+	Name       string
+	Package    string
+	Output     string
+	Imports    []string
+	Comparable bool
+	TestVals   []string
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("gollections-gen: ")
+	var configs []*config
+	if len(os.Args) > 1 {
+		cfg, err := parseConfig(os.Args[1:], flag.ExitOnError)
+		if err != nil {
+			log.Fatal(err)
+		}
+		configs = append(configs, cfg)
+	} else {
+		var err error
+		if configs, err = scanDirectives("."); err != nil {
+			log.Fatal(err)
+		}
+		if len(configs) == 0 {
+			log.Fatalf("No flags given and no %s directive found", directive)
+		}
+	}
+	for _, cfg := range configs {
+		if err := generateFiles(cfg, "."); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// Parse the flags of one generated type
+func parseConfig(args []string, handling flag.ErrorHandling) (*config, error) {
+	fs := flag.NewFlagSet("gollections-gen", handling)
+	cfg := &config{}
+	var imports, testVals string
+	fs.StringVar(&cfg.Type, "type", "", "Element type (required), ie: int, *User, time.Time")
+	fs.StringVar(&cfg.Name, "name", "", "Name of the generated type (required), ie: IntSlice")
+	fs.StringVar(&cfg.Package, "package", "", "Package of the generated file (default: package of the current directory)")
+	fs.StringVar(&cfg.Output, "output", "", "Generated file name (default: <name>_gen.go, lower case)")
+	fs.StringVar(&imports, "import", "", "Comma separated import paths needed by the element type")
+	fs.BoolVar(&cfg.Comparable, "comparable", false, "Element type supports ==, used by the default Equals")
+	fs.StringVar(&testVals, "test", "", "Comma separated values of the element type, generates a test file when set")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if cfg.Type == "" || cfg.Name == "" {
+		return nil, fmt.Errorf("-type and -name are required")
+	}
+	if cfg.Output == "" {
+		cfg.Output = strings.ToLower(cfg.Name) + "_gen.go"
+	}
+	if imports != "" {
+		cfg.Imports = strings.Split(imports, ",")
+	}
+	if testVals != "" {
+		cfg.TestVals = splitValues(testVals)
+		if len(cfg.TestVals) < 3 {
+			return nil, fmt.Errorf("-test needs at least 3 values, got %d", len(cfg.TestVals))
+		}
+	}
+	return cfg, nil
+}
+
+// Find the //gollections:gen directives in the go files of dir
+func scanDirectives(dir string) ([]*config, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	var configs []*config
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		for i, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if !strings.HasPrefix(line, directive+" ") {
+				continue
+			}
+			var cfg *config
+			args, err := splitArgs(line[len(directive):])
+			if err == nil {
+				cfg, err = parseConfig(args, flag.ContinueOnError)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %v", file, i+1, err)
+			}
+			configs = append(configs, cfg)
+		}
+	}
+	return configs, nil
+}
+
+// Generate the file(s) of a config into dir
+func generateFiles(cfg *config, dir string) error {
+	if cfg.Package == "" {
+		pkg, err := packageName(dir)
+		if err != nil {
+			return err
+		}
+		cfg.Package = pkg
+	}
+	var buf bytes.Buffer
+	if err := generate(&buf, sliceTemplate, cfg); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, cfg.Output), buf.Bytes(), 0644); err != nil {
+		return err
+	}
+	if len(cfg.TestVals) == 0 {
+		return nil
+	}
+	buf.Reset()
+	if err := generate(&buf, sliceTestTemplate, cfg); err != nil {
+		return err
+	}
+	testFile := strings.TrimSuffix(cfg.Output, ".go") + "_test.go"
+	return os.WriteFile(filepath.Join(dir, testFile), buf.Bytes(), 0644)
+}
+
+// Execute the template with the config and gofmt the result
+func generate(w io.Writer, tpl *template.Template, cfg *config) error {
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, cfg); err != nil {
+		return err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("generated invalid code for %s (check -type and -test): %v", cfg.Name, err)
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// Name of the package in dir, from $GOPACKAGE (set by go generate) or the go files
+func packageName(dir string) (string, error) {
+	if pkg := os.Getenv("GOPACKAGE"); pkg != "" {
+		return pkg, nil
+	}
+	pkgs, err := parser.ParseDir(token.NewFileSet(), dir, nil, parser.PackageClauseOnly)
+	if err != nil {
+		return "", err
+	}
+	for name := range pkgs {
+		if !strings.HasSuffix(name, "_test") {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("Could not find the package name, use -package")
+}
+
+// Split the arguments of a directive on the spaces, ignoring those nested in
+// parenthesis, brackets, braces or strings, ie: -test=User{1, "a"},User{2, "b"}
+// An argument, or flag value, which is a single quoted string is unquoted.
+func splitArgs(str string) ([]string, error) {
+	var args []string
+	depth := 0
+	var quote rune
+	start := -1
+	for i, r := range str {
+		if start < 0 {
+			if unicode.IsSpace(r) {
+				continue
+			}
+			start = i
+		}
+		switch {
+		case quote != 0:
+			if r == quote && str[i-1] != '\\' {
+				quote = 0
+			}
+		case r == '"' || r == '\'' || r == '`':
+			quote = r
+		case r == '(' || r == '[' || r == '{':
+			depth++
+		case r == ')' || r == ']' || r == '}':
+			depth--
+		case unicode.IsSpace(r) && depth <= 0:
+			args = append(args, unquoteArg(str[start:i]))
+			start = -1
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("Unterminated string in %q", strings.TrimSpace(str))
+	}
+	if start >= 0 {
+		args = append(args, unquoteArg(str[start:]))
+	}
+	return args, nil
+}
+
+// Unquote an argument (ie: "a b") or a flag value (ie: -import="a b") which is a
+// single quoted string, other arguments are returned as is.
+func unquoteArg(arg string) string {
+	prefix, val := "", arg
+	if eq := strings.Index(arg, "="); strings.HasPrefix(arg, "-") && eq > 0 {
+		prefix, val = arg[:eq+1], arg[eq+1:]
+	}
+	if unquoted, err := strconv.Unquote(val); err == nil {
+		return prefix + unquoted
+	}
+	return arg
+}
+
+// Split a comma separated list of go expressions, ignoring the commas nested
+// in parenthesis, brackets, braces or strings, ie: "T{1, 2}, T{3, 4}"
+func splitValues(str string) []string {
+	var vals []string
+	depth := 0
+	var quote rune
+	start := 0
+	for i, r := range str {
+		switch {
+		case quote != 0:
+			if r == quote && (i == 0 || str[i-1] != '\\') {
+				quote = 0
+			}
+		case r == '"' || r == '\'' || r == '`':
+			quote = r
+		case r == '(' || r == '[' || r == '{':
+			depth++
+		case r == ')' || r == ']' || r == '}':
+			depth--
+		case r == ',' && depth == 0:
+			vals = append(vals, strings.TrimSpace(str[start:i]))
+			start = i + 1
+		}
+	}
+	return append(vals, strings.TrimSpace(str[start:]))
+}
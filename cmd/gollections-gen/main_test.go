@@ -0,0 +1,93 @@
+// History: Oct 18 26 agent Creation
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseConfig(t *testing.T) {
+
+	convey.Convey("Flags", t, func() {
+		cfg, err := parseConfig([]string{"-type=*User", "-name=UserSlice", "-import=a/b,c/d",
+			"-test=&User{1,2},&User{3},&User{4}"}, flag.ContinueOnError)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(cfg.Type, convey.ShouldEqual, "*User")
+		convey.So(cfg.Output, convey.ShouldEqual, "userslice_gen.go")
+		convey.So(cfg.Imports, convey.ShouldResemble, []string{"a/b", "c/d"})
+		convey.So(cfg.TestVals, convey.ShouldResemble, []string{"&User{1,2}", "&User{3}", "&User{4}"})
+		_, err = parseConfig([]string{"-type=int"}, flag.ContinueOnError)
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = parseConfig([]string{"-type=int", "-name=X", "-test=1,2"}, flag.ContinueOnError)
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+
+	convey.Convey("splitValues", t, func() {
+		convey.So(splitValues(`"a,b", 'c', f(1, 2), []int{3, 4}`), convey.ShouldResemble,
+			[]string{`"a,b"`, `'c'`, `f(1, 2)`, `[]int{3, 4}`})
+	})
+
+	convey.Convey("splitArgs", t, func() {
+		args, err := splitArgs(` -type=User  -test=User{1, "a b"},User{2, "}"} -import="my/x y" "-name=N" `)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(args, convey.ShouldResemble,
+			[]string{`-type=User`, `-test=User{1, "a b"},User{2, "}"}`, `-import=my/x y`, `-name=N`})
+		args, _ = splitArgs(`-test="a","b","c"`)
+		convey.So(args, convey.ShouldResemble, []string{`-test="a","b","c"`})
+		_, err = splitArgs(`-test=User{1, "a}`)
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+}
+
+func TestGenerate(t *testing.T) {
+
+	convey.Convey("Directives", t, func() {
+		dir := t.TempDir()
+		src := "package model\n\n//go:generate gollections-gen\n" +
+			"//gollections:gen -type=int -name=IntSlice -comparable -test=1,2,3\n" +
+			"//gollections:gen -type=*User -name=UserSlice -test=&User{1, \"a\"},&User{2, \"b c\"},&User{3, \"d\"}\n\n" +
+			"type User struct {\n\tID   int\n\tName string\n}\n"
+		convey.So(os.WriteFile(filepath.Join(dir, "model.go"), []byte(src), 0644), convey.ShouldBeNil)
+		configs, err := scanDirectives(dir)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(configs), convey.ShouldEqual, 2)
+		for _, cfg := range configs {
+			convey.So(generateFiles(cfg, dir), convey.ShouldBeNil)
+		}
+		convey.So(configs[0].Package, convey.ShouldEqual, "model")
+		paths, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+		convey.So(len(paths), convey.ShouldEqual, 5)
+		// The generated tests must build and pass, run them in a temporary module
+		if _, err := exec.LookPath("go"); err != nil {
+			convey.Println("go command not found, not running the generated tests")
+			return
+		}
+		gomod := "module model\n\ngo 1.18\n"
+		convey.So(os.WriteFile(filepath.Join(dir, "go.mod"), []byte(gomod), 0644), convey.ShouldBeNil)
+		cmd := exec.Command("go", "test", "./...")
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=")
+		out, err := cmd.CombinedOutput()
+		convey.So(string(out), convey.ShouldContainSubstring, "ok")
+		convey.So(err, convey.ShouldBeNil)
+	})
+
+	convey.Convey("Specialized signatures", t, func() {
+		cfg, _ := parseConfig([]string{"-type=int", "-name=IntSlice", "-package=p"}, flag.ContinueOnError)
+		var buf bytes.Buffer
+		convey.So(generate(&buf, sliceTemplate, cfg), convey.ShouldBeNil)
+		code := buf.String()
+		convey.So(code, convey.ShouldContainSubstring, "func (s *IntSlice) Get(idx int) int {")
+		convey.So(code, convey.ShouldContainSubstring, "func (s *IntSlice) To() []int {")
+		convey.So(code, convey.ShouldContainSubstring, "reflect.DeepEqual")
+		convey.So(strings.HasPrefix(code, "// Code generated by gollections-gen"), convey.ShouldBeTrue)
+	})
+}
@@ -0,0 +1,635 @@
+// History: Oct 18 26 agent Creation
+
+package main
+
+import (
+	"text/template"
+)
+
+// Type specialized copy of gollections.Slice (slice.go)
+// Keep in sync with slice.go
+var sliceTemplate = template.Must(template.New("slice").Parse(`// Code generated by gollections-gen -type={{.Type}} -name={{.Name}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+{{- if not .Comparable}}
+	"reflect"
+{{- end}}
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+
+// Slice of {{.Type}}, type specialized copy of gollections.Slice
+// Note: Satisfies sort.Interface so can use sort, search as long as Compare is
+// implemented
+// Uses the same index model as gollections.Slice: element indexes in [-len, len-1],
+// insert positions in [-len, len] and inclusive ranges.
+type {{.Name}} struct {
+
+	// internal slice that hold the items
+	slice []{{.Type}}
+
+	// Returns whether two items are equal
+{{- if .Comparable}}
+	// Default implementation uses ==
+{{- else}}
+	// Default implementation uses reflect.DeepEqual
+{{- end}}
+	Equals func(a, b {{.Type}}) bool
+
+	// Optional comparator function, must return 0 if a==b; -1 if a < b; 1 if a>b
+	// **Nil by default**
+	// **MUST** be defined for sorting to work.
+	Compare func(a, b {{.Type}}) int
+}
+
+// Initialize a new empty {{.Name}}
+func New{{.Name}}() *{{.Name}} {
+	s := &{{.Name}}{}
+{{- if .Comparable}}
+	s.Equals = func(a, b {{.Type}}) bool { return a == b }
+{{- else}}
+	s.Equals = func(a, b {{.Type}}) bool { return reflect.DeepEqual(a, b) }
+{{- end}}
+	return s
+}
+
+// Return true if f returns true for all of the items in the list.
+func (s *{{.Name}}) All(f func({{.Type}}) bool) bool {
+	for _, e := range s.slice {
+		if !f(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// Return true if c returns true for any(at least 1) of the items in the list
+func (s *{{.Name}}) Any(f func({{.Type}}) bool) bool {
+	for _, e := range s.slice {
+		if f(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// Append a single value (in place)
+// Return the slice pointer to allow method chaining.
+func (s *{{.Name}}) Append(elem {{.Type}}) *{{.Name}} {
+	s.slice = append(s.slice, elem)
+	return s
+}
+
+// Append several values (in place)
+// Return the slice pointer to allow method chaining.
+func (s *{{.Name}}) AppendAll(elems ...{{.Type}}) *{{.Name}} {
+	s.slice = append(s.slice, elems...)
+	return s
+}
+
+// Append another {{.Name}} to this slice
+// Return the slice pointer to allow method chaining.
+func (s *{{.Name}}) AppendSlice(slice *{{.Name}}) *{{.Name}} {
+	s.slice = append(s.slice, slice.slice...)
+	return s
+}
+
+// Current slice capacity
+func (s *{{.Name}}) Cap() int {
+	return cap(s.slice)
+}
+
+// Clear (empty) the list
+// Return the slice pointer to allow method chaining.
+func (s *{{.Name}}) Clear() *{{.Name}} {
+	s.slice = nil
+	return s
+}
+
+// Create and return a clone of this slice
+func (s *{{.Name}}) Clone() *{{.Name}} {
+	clone := New{{.Name}}()
+	clone.Equals = s.Equals
+	clone.Compare = s.Compare
+	clone.slice = append(clone.slice, s.slice...)
+	return clone
+}
+
+// Clone part of this slice into a new {{.Name}}
+// From and To are both inclusive
+func (s *{{.Name}}) CloneRange(from, to int) *{{.Name}} {
+	var err error
+	if from, to, err = s.handleRange(from, to); err != nil {
+		panic(err.Error())
+	}
+	clone := New{{.Name}}()
+	clone.slice = append(clone.slice, s.slice[from:to+1]...)
+	return clone
+}
+
+// Does the slice contain the given element (by equality)
+// Note, this uses simple iteration, use sort methods if needing more performance
+func (s *{{.Name}}) Contains(elem {{.Type}}) bool {
+	return s.IndexOf(elem) != -1
+}
+
+// Does the slice contain all the given values
+func (s *{{.Name}}) ContainsAll(elems ...{{.Type}}) bool {
+	for _, elem := range elems {
+		if !s.Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+// Does the slice contain at least one of the given values
+func (s *{{.Name}}) ContainsAny(elems ...{{.Type}}) bool {
+	for _, elem := range elems {
+		if s.Contains(elem) {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply the function to the whole slice (in order)
+// If the function returns true (stop), iteration will stop
+func (s *{{.Name}}) Each(f func(int, {{.Type}}) (stop bool)) {
+	if len(s.slice) == 0 {
+		return
+	}
+	s.EachRange(0, len(s.slice)-1, f)
+}
+
+// Apply the function to the slice range
+// From and To are both inclusive
+// if from is > to it will iterate in reversed order
+// If the function returns true (stop), iteration will stop
+func (s *{{.Name}}) EachRange(from, to int, f func(int, {{.Type}}) (stop bool)) {
+	var err error
+	if from, err = s.handleIndex(from); err != nil {
+		panic(err.Error())
+	}
+	if to, err = s.handleIndex(to); err != nil {
+		panic(err.Error())
+	}
+	step := 1
+	steps := to - from
+	if from > to {
+		step = -1
+		steps = -steps
+	}
+	for i := 0; i != steps+1; i++ {
+		if f(from, s.slice[from]) {
+			break
+		}
+		from += step
+	}
+}
+
+// Apply the function to the whole slice (reverse order)
+// If the function returns true (stop), iteration will stop
+func (s *{{.Name}}) Eachr(f func(int, {{.Type}}) (stop bool)) {
+	if len(s.slice) == 0 {
+		return
+	}
+	s.EachRange(len(s.slice)-1, 0, f)
+}
+
+// Fill(append to) the slice with 'count' times the 'elem' value (in place)
+// Return the slice pointer to allow method chaining.
+func (s *{{.Name}}) Fill(elem {{.Type}}, count int) *{{.Name}} {
+	for i := 0; i != count; i++ {
+		s.Append(elem)
+	}
+	return s
+}
+
+// Apply a function to find an element in the slice (iteratively)
+// Returns the index if found, or -1 if no matches.
+// The function is expected to return true when the index is found.
+func (s *{{.Name}}) Find(f func(int, {{.Type}}) (found bool)) (index int) {
+	for i, e := range s.slice {
+		if f(i, e) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Apply a function to find all element in the slice for which the function returns true
+// Returns a new {{.Name}} made of the matches.
+func (s *{{.Name}}) FindAll(f func(int, {{.Type}}) (found bool)) *{{.Name}} {
+	results := New{{.Name}}()
+	for i, e := range s.slice {
+		if f(i, e) {
+			results.slice = append(results.slice, e)
+		}
+	}
+	return results
+}
+
+// Return this slice first element
+// Will panic if slice is empty
+func (s *{{.Name}}) First() {{.Type}} {
+	return s.Get(0)
+}
+
+// Return slice[idx]
+// If idx is negative then idx element from the end -> slice[len(slice)+idx]
+// ie Get(-1) would return the last element
+func (s *{{.Name}}) Get(idx int) {{.Type}} {
+	var err error
+	if idx, err = s.handleIndex(idx); err != nil {
+		panic(err.Error())
+	}
+	return s.slice[idx]
+}
+
+// Return the (lowest) index of given element (using Equals() method)
+// Return -1 if the element is not part of the slice
+// Note, this uses simple iteration, use sort methods if needing more performance
+func (s *{{.Name}}) IndexOf(elem {{.Type}}) int {
+	for i, e := range s.slice {
+		if s.Equals(e, elem) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Insert the element before index idx
+// Can use negative index, idx = Len() appends the element
+// Return the slice pointer to allow method chaining.
+func (s *{{.Name}}) Insert(idx int, elem {{.Type}}) *{{.Name}} {
+	return s.InsertAll(idx, elem)
+}
+
+// Insert All the element before index idx
+// Can use negative index, idx = Len() appends the elements
+// Return the slice pointer to allow method chaining.
+func (s *{{.Name}}) InsertAll(idx int, elems ...{{.Type}}) *{{.Name}} {
+	var err error
+	if idx, err = s.handleInsertIndex(idx); err != nil {
+		panic(err.Error())
+	}
+	s.slice = append(s.slice, make([]{{.Type}}, len(elems))...)
+	copy(s.slice[idx+len(elems):], s.slice[idx:])
+	copy(s.slice[idx:], elems)
+	return s
+}
+
+// Insert All the element of the slice before index idx
+// Can use negative index, idx = Len() appends the elements
+// Return the slice pointer to allow method chaining.
+func (s *{{.Name}}) InsertSlice(idx int, slice *{{.Name}}) *{{.Name}} {
+	return s.InsertAll(idx, slice.slice...)
+}
+
+// Is this slice empty
+func (s *{{.Name}}) IsEmpty() bool {
+	return len(s.slice) == 0
+}
+
+// Create a string by joining all the elements with the given separator
+// Note: Use fmt.Sprintf("%v", e) to get each element as a string
+func (s *{{.Name}}) Join(sep string) string {
+	var buf bytes.Buffer
+	for i, e := range s.slice {
+		if i != 0 {
+			buf.WriteString(sep)
+		}
+		buf.WriteString(fmt.Sprintf("%v", e))
+	}
+	return buf.String()
+}
+
+// Return this slice last element
+// Will panic if slice is empty
+func (s *{{.Name}}) Last() {{.Type}} {
+	return s.Get(-1)
+}
+
+// Length of this slice
+// Also used for impl of sort.Interface
+func (s *{{.Name}}) Len() int {
+	return len(s.slice)
+}
+
+// Check if element at index a < b (used as impl of sort.Interface)
+// S.Compare must be defined !
+func (s *{{.Name}}) Less(a, b int) bool {
+	if s.Compare == nil {
+		panic("{{.Name}}.Compare function was not implemented !")
+	}
+	return s.Compare(s.Get(a), s.Get(b)) == -1
+}
+
+// Return the minimum value in the slice (panic if slice is empty)
+// NOTE: Compare function **MUST** be implemented
+func (s *{{.Name}}) Min() {{.Type}} {
+	if s.IsEmpty() {
+		panic("Can't find Min of empty slice !")
+	}
+	minIdx := 0
+	for i := 1; i < len(s.slice); i++ {
+		if s.Less(i, minIdx) {
+			minIdx = i
+		}
+	}
+	return s.slice[minIdx]
+}
+
+// Return the maximum value in the slice (panic if slice is empty)
+// NOTE: Compare function **MUST** be implemented
+func (s *{{.Name}}) Max() {{.Type}} {
+	if s.IsEmpty() {
+		panic("Can't find Max of empty slice !")
+	}
+	maxIdx := 0
+	for i := 1; i < len(s.slice); i++ {
+		if s.Less(maxIdx, i) {
+			maxIdx = i
+		}
+	}
+	return s.slice[maxIdx]
+}
+
+// Return the last element
+// Will panic if slice is empty
+func (s *{{.Name}}) Peek() {{.Type}} {
+	return s.Last()
+}
+
+// Pop (return & remove) the last element
+// Will panic if slice is empty
+func (s *{{.Name}}) Pop() {{.Type}} {
+	elem := s.Last()
+	var zero {{.Type}}
+	s.slice[len(s.slice)-1] = zero
+	s.slice = s.slice[:len(s.slice)-1]
+	return elem
+}
+
+// Push an elem at the end of the slice (same as Append)
+func (s *{{.Name}}) Push(elem {{.Type}}) {
+	s.Append(elem)
+}
+
+// Reduce is used to iterate through every item in the list to reduce the list
+// into a single value called the reduction.
+// The initial value (startVal) of the reduction is passed in as the init parameter
+// then passed to the closure along with each item (which returns the updated reduction)
+func (s *{{.Name}}) Reduce(startVal interface{}, f func(reduction interface{}, index int, elem {{.Type}}) interface{}) interface{} {
+	reduction := startVal
+	for i, e := range s.slice {
+		reduction = f(reduction, i, e)
+	}
+	return reduction
+}
+
+// Remove the element at the given index (in place)
+// Can use negative index
+// Return the slice pointer to allow method chaining.
+func (s *{{.Name}}) RemoveAt(idx int) *{{.Name}} {
+	var err error
+	if idx, err = s.handleIndex(idx); err != nil {
+		panic(err.Error())
+	}
+	copy(s.slice[idx:], s.slice[idx+1:])
+	s.slice = s.slice[:len(s.slice)-1]
+	return s
+}
+
+// Remove, in place, the first element found by value equality (found by IndexOf method)
+// Return the slice pointer to allow method chaining.
+func (s *{{.Name}}) RemoveElem(elem {{.Type}}) *{{.Name}} {
+	idx := s.IndexOf(elem)
+	if idx >= 0 {
+		s.RemoveAt(idx)
+	}
+	return s
+}
+
+// Remove, in place, all elements by value equality (using Equals function)
+// Return the slice pointer to allow method chaining.
+func (s *{{.Name}}) RemoveElems(elem {{.Type}}) *{{.Name}} {
+	return s.RemoveFunc(func(idx int, e {{.Type}}) bool {
+		return s.Equals(elem, e)
+	})
+}
+
+// Remove, in place, the elements that match the function (where the function return true)
+func (s *{{.Name}}) RemoveFunc(f func(idx int, elem {{.Type}}) bool) *{{.Name}} {
+	for i := 0; i < len(s.slice); i++ {
+		if f(i, s.slice[i]) {
+			s.RemoveAt(i)
+			i--
+		}
+	}
+	return s
+}
+
+// Remove the elements within the given index range (in place)
+// From and To are both inclusive
+// Return the slice pointer to allow method chaining.
+func (s *{{.Name}}) RemoveRange(from, to int) *{{.Name}} {
+	var err error
+	if from, to, err = s.handleRange(from, to); err != nil {
+		panic(err.Error())
+	}
+	copy(s.slice[from:], s.slice[to+1:])
+	s.slice = s.slice[:len(s.slice)-(to-from+1)]
+	return s
+}
+
+// Reverse in place, the slice in place (first element becomes last etc...)
+// Return the slice pointer to allow method chaining.
+func (s *{{.Name}}) Reverse() *{{.Name}} {
+	for start, end := 0, len(s.slice)-1; end > start; start, end = start+1, end-1 {
+		s.slice[start], s.slice[end] = s.slice[end], s.slice[start]
+	}
+	return s
+}
+
+// Set the element at the given index
+// Can use negative index
+// Return the slice pointer to allow method chaining.
+func (s *{{.Name}}) Set(idx int, elem {{.Type}}) *{{.Name}} {
+	var err error
+	if idx, err = s.handleIndex(idx); err != nil {
+		panic(err.Error())
+	}
+	s.slice[idx] = elem
+	return s
+}
+
+// Returns pointer to the raw underlying slice ([]{{.Type}})
+func (s *{{.Name}}) Slice() *[]{{.Type}} {
+	return &s.slice
+}
+
+// impl String interface
+func (s *{{.Name}}) String() string {
+	return fmt.Sprintf("{{.Name}}[%d] %v", len(s.slice), s.slice)
+}
+
+// Swap 2 elements (used as impl of sort.Interface)
+// Panics if the indexes are out of bounds
+func (s *{{.Name}}) Swap(a, b int) {
+	var err error
+	if a, err = s.handleIndex(a); err != nil {
+		panic(err.Error())
+	}
+	if b, err = s.handleIndex(b); err != nil {
+		panic(err.Error())
+	}
+	s.slice[a], s.slice[b] = s.slice[b], s.slice[a]
+}
+
+// Return a copy of the slice content as a plain slice
+// Returns an empty slice if the slice is empty.
+func (s *{{.Name}}) To() []{{.Type}} {
+	if len(s.slice) == 0 {
+		return []{{.Type}}{}
+	}
+	return s.ToRange(0, len(s.slice)-1)
+}
+
+// Same as To() but only get a subset(range) of the slice
+// From and To are both inclusive
+// Note that from and to can use negative index to indicate "from the end"
+func (s *{{.Name}}) ToRange(from, to int) []{{.Type}} {
+	var err error
+	if from, to, err = s.handleRange(from, to); err != nil {
+		panic(err.Error())
+	}
+	return append([]{{.Type}}{}, s.slice[from:to+1]...)
+}
+
+// Validate the element index is in the slice bounds [-len, len-1]
+// Also turn negative indexes into index from the end of the slice (-1 = last)
+func (s *{{.Name}}) handleIndex(idx int) (int, error) {
+	i := idx
+	if i < 0 {
+		i = len(s.slice) + i
+	}
+	if i >= len(s.slice) || i < 0 {
+		return i, errors.New(fmt.Sprintf("Invalid slice index: %d", idx))
+	}
+	return i, nil
+}
+
+// Validate the insert position is in [-len, len] (len = after the last element)
+// Also turn negative positions into position from the end of the slice (-1 = before last)
+func (s *{{.Name}}) handleInsertIndex(idx int) (int, error) {
+	i := idx
+	if i < 0 {
+		i = len(s.slice) + i
+	}
+	if i > len(s.slice) || i < 0 {
+		return i, errors.New(fmt.Sprintf("Invalid slice index: %d", idx))
+	}
+	return i, nil
+}
+
+// Validate an inclusive range, both ends must be valid element indexes
+// and from must not be after to (once negative indexes are resolved)
+func (s *{{.Name}}) handleRange(from, to int) (int, int, error) {
+	var err error
+	f, t := from, to
+	if f, err = s.handleIndex(f); err != nil {
+		return f, t, err
+	}
+	if t, err = s.handleIndex(t); err != nil {
+		return f, t, err
+	}
+	if f > t {
+		return f, t, errors.New(fmt.Sprintf("Invalid slice range: %d to %d", from, to))
+	}
+	return f, t, nil
+}
+`))
+
+// Tests of the generated type, using the -test values
+var sliceTestTemplate = template.Must(template.New("slicetest").Parse(`// Code generated by gollections-gen -type={{.Type}} -name={{.Name}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"testing"
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+
+func test{{.Name}}Vals() []{{.Type}} {
+	return []{{.Type}}{ {{range $i, $v := .TestVals}}{{if $i}}, {{end}}{{$v}}{{end}} }
+}
+
+func Test{{.Name}}(t *testing.T) {
+	vals := test{{.Name}}Vals()
+	a, b, c := vals[0], vals[1], vals[2]
+	s := New{{.Name}}()
+	if !s.IsEmpty() {
+		t.Fatal("new slice should be empty")
+	}
+	s.AppendAll(a, b).Append(c)
+	if s.Len() != 3 || !s.Equals(s.Get(0), a) || !s.Equals(s.Get(-1), c) {
+		t.Fatalf("unexpected content after append: %v", s)
+	}
+	if !s.Equals(s.First(), a) || !s.Equals(s.Last(), c) || !s.Equals(s.Peek(), c) {
+		t.Fatalf("unexpected first/last: %v", s)
+	}
+	if s.IndexOf(b) != 1 || !s.Contains(c) || !s.ContainsAll(a, b, c) {
+		t.Fatalf("unexpected search results: %v", s)
+	}
+	s.Insert(1, c).RemoveAt(0)
+	if !s.Equals(s.Get(0), c) || s.Len() != 3 {
+		t.Fatalf("unexpected content after insert/remove: %v", s)
+	}
+	clone := s.Clone()
+	s.Reverse()
+	if !s.Equals(s.Get(0), clone.Get(-1)) || !s.Equals(s.Get(-1), clone.Get(0)) {
+		t.Fatalf("unexpected content after reverse: %v (clone: %v)", s, clone)
+	}
+	s.RemoveElems(c)
+	if s.Len() != 1 || !s.Equals(s.Pop(), b) || !s.IsEmpty() {
+		t.Fatalf("unexpected content after remove: %v", s)
+	}
+	s.AppendSlice(clone).Fill(a, 2)
+	raw := s.To()
+	if len(raw) != 5 || !s.Equals(raw[4], a) {
+		t.Fatalf("unexpected To() result: %v", raw)
+	}
+	found := s.FindAll(func(i int, e {{.Type}}) bool { return s.Equals(e, a) })
+	if found.Len() != 2 {
+		t.Fatalf("unexpected FindAll() result: %v", found)
+	}
+	count := s.Reduce(0, func(reduction interface{}, i int, e {{.Type}}) interface{} {
+		return reduction.(int) + 1
+	})
+	if count != 5 {
+		t.Fatalf("unexpected Reduce() result: %v", count)
+	}
+	s.Insert(s.Len(), a).Set(-1, b).RemoveRange(1, -2) // c, b
+	if s.Len() != 2 || !s.Equals(s.Get(0), c) || !s.Equals(s.Get(1), b) {
+		t.Fatalf("unexpected content after insert/set/remove range: %v", s)
+	}
+	s.Clear()
+	if s.Len() != 0 || len(s.To()) != 0 {
+		t.Fatal("slice should be empty after Clear")
+	}
+	s.Each(func(i int, e {{.Type}}) bool {
+		t.Fatal("Each should not iterate over an empty slice")
+		return true
+	})
+}
+`))
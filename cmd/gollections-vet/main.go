@@ -0,0 +1,17 @@
+// History: Oct 18 26 agent Creation
+
+// Command gollections-vet runs the gollectionsvet analyzer, it's meant to be
+// used as a go vet tool:
+//
+//	go vet -vettool=$(which gollections-vet) ./...
+package main
+
+import (
+	"github.com/tcolar/gollections/gollectionsvet"
+
+	"golang.org/x/tools/go/analysis/unitchecker"
+)
+
+func main() {
+	unitchecker.Main(gollectionsvet.Analyzer)
+}
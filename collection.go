@@ -0,0 +1,114 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+// Interfaces shared by the gollections containers, so helpers can accept any
+// of them.
+// Note: The mutating methods (Append, Clear ...) are not part of those
+// interfaces since they return the concrete container type (for chaining),
+// Clearable provides Reset() instead of Clear().
+
+// Methods common to all the containers
+type Collection interface {
+	// Number of elements (or entries)
+	Len() int
+	IsEmpty() bool
+	String() string
+}
+
+// Containers whose elements can be iterated in a defined order
+// ie: Slice, RingBuffer, PersistentVector, BitSet
+type Iterable interface {
+	// Apply the function to the elements, in order
+	// If the function returns true (stop), iteration will stop
+	Each(f func(int, interface{}) (stop bool))
+	// Is the element one of the iterated elements
+	Contains(elem interface{}) bool
+}
+
+// Mutable containers that can be emptied
+// ie: Slice, Map, BiMap, MultiMap, RingBuffer, Counter, Trie, BitSet, SparseBitSet
+// Reset is an alias of the container Clear (ClearAll for the bitsets), minus the
+// returned pointer.
+type Clearable interface {
+	// Remove all the elements
+	Reset()
+}
+
+// Ordered containers whose elements can be accessed by index
+// ie: Slice, SliceView, RingBuffer, PersistentVector
+type Sequence interface {
+	Collection
+	Iterable
+	// Set value of ptr to the element at index idx (negative idx is from the end)
+	Get(idx int, ptr interface{})
+}
+
+// Sequences that can be searched
+// ie: Slice, SliceView
+type List interface {
+	Sequence
+	IndexOf(elem interface{}) int
+	First(ptr interface{})
+	Last(ptr interface{})
+	Find(f func(int, interface{}) (found bool)) (index int)
+	FindAll(f func(int, interface{}) (found bool)) *Slice
+	Join(sep string) string
+}
+
+// Containers mapping keys to values
+// ie: Map, MapView, BiMap, PersistentMap
+type MapLike interface {
+	Collection
+	ContainsKey(key interface{}) bool
+	// Set value of ptr to the value associated with key, false if none
+	Get(key interface{}, ptr interface{}) (found bool)
+	Keys() *Slice
+	Vals() *Slice
+	// Apply the function to the entries
+	// If the function returns true (stop), iteration will stop
+	Each(f func(key, val interface{}) (stop bool))
+}
+
+// Copy the elements of any Iterable into a new Slice
+func Collect(it Iterable) *Slice {
+	s := NewSlice()
+	it.Each(func(i int, e interface{}) bool {
+		s.slice = append(s.slice, e)
+		return false
+	})
+	return s
+}
+
+// Make sure the containers implement the interfaces
+var (
+	_ List = (*Slice)(nil)
+	_ List = (*SliceView)(nil)
+
+	_ Sequence = (*RingBuffer)(nil)
+	_ Sequence = (*PersistentVector)(nil)
+
+	_ Iterable   = (*BitSet)(nil)
+	_ Collection = (*BitSet)(nil)
+	_ Iterable   = (*SparseBitSet)(nil)
+	_ Collection = (*SparseBitSet)(nil)
+
+	_ MapLike = (*Map)(nil)
+	_ MapLike = (*MapView)(nil)
+	_ MapLike = (*BiMap)(nil)
+	_ MapLike = (*PersistentMap)(nil)
+
+	_ Collection = (*Counter)(nil)
+	_ Collection = (*MultiMap)(nil)
+	_ Collection = (*Trie)(nil)
+
+	_ Clearable = (*Slice)(nil)
+	_ Clearable = (*Map)(nil)
+	_ Clearable = (*BiMap)(nil)
+	_ Clearable = (*MultiMap)(nil)
+	_ Clearable = (*RingBuffer)(nil)
+	_ Clearable = (*Counter)(nil)
+	_ Clearable = (*Trie)(nil)
+	_ Clearable = (*BitSet)(nil)
+	_ Clearable = (*SparseBitSet)(nil)
+)
@@ -0,0 +1,84 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestCollections(t *testing.T) {
+
+	convey.Convey("Collect", t, func() {
+		iterables := []Iterable{
+			NewSlice().AppendAll(1, 2, 3),
+			NewSlice().AppendAll(1, 2, 3).ReadOnly(),
+			NewPersistentVector().AppendAll(1, 2, 3),
+			NewBitSet().SetRange(1, 3),
+			NewSparseBitSet().SetRange(1, 3),
+		}
+		for _, it := range iterables {
+			convey.So(Collect(it).Join(","), convey.ShouldEqual, "1,2,3")
+			convey.So(it.Contains(2), convey.ShouldBeTrue)
+			convey.So(it.Contains(4), convey.ShouldBeFalse)
+			convey.So(it.Contains("2"), convey.ShouldBeFalse)
+		}
+		ring := NewRingBuffer(2, RingOverwrite)
+		ring.Put(1)
+		ring.Put(2)
+		ring.Put(3)
+		convey.So(ring.Contains(3), convey.ShouldBeTrue)
+		convey.So(ring.Contains(1), convey.ShouldBeFalse)
+	})
+
+	convey.Convey("Clearable", t, func() {
+		bimap := NewBiMap()
+		bimap.Put("a", 1)
+		ring := NewRingBuffer(2, RingOverwrite)
+		ring.Put(1)
+		clearables := []Clearable{
+			NewSlice().AppendAll(1, 2),
+			NewMap().Set(1, 1),
+			bimap,
+			NewMultiMap().PutAll("a", 1, 2),
+			ring,
+			NewCounter().AddAll(1, 2),
+			NewTrie().Put("a", 1),
+			NewBitSet().SetRange(1, 3),
+			NewSparseBitSet().SetRange(1, 3),
+		}
+		for _, c := range clearables {
+			convey.So(c.(Collection).IsEmpty(), convey.ShouldBeFalse)
+			c.Reset()
+			convey.So(c.(Collection).IsEmpty(), convey.ShouldBeTrue)
+		}
+	})
+
+	convey.Convey("Sizes", t, func() {
+		collections := []Collection{
+			NewSlice().AppendAll(1, 2),
+			NewMap().Set(1, 1).Set(2, 2),
+			NewMultiMap().PutAll("a", 1, 2),
+			NewCounter().AddAll(1, 2),
+			NewTrie().Put("a", 1).Put("b", 2),
+		}
+		for _, c := range collections {
+			convey.So(c.Len(), convey.ShouldEqual, 2)
+			convey.So(c.IsEmpty(), convey.ShouldBeFalse)
+		}
+	})
+
+	convey.Convey("MapLike", t, func() {
+		var result int
+		maps := []MapLike{
+			testMap(),
+			testMap().ReadOnly(),
+			NewPersistentMap().Set("a", 1).Set("b", 2).Set("c", 3),
+		}
+		for _, m := range maps {
+			convey.So(m.Get("b", &result), convey.ShouldBeTrue)
+			convey.So(result, convey.ShouldEqual, 2)
+			convey.So(m.Keys().Len(), convey.ShouldEqual, 3)
+		}
+	})
+}
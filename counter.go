@@ -0,0 +1,312 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Counter (aka Bag or multiset) counts the occurrences of elements
+// Elements are matched with Equals, using iteration unless a Hash function is
+// provided in which case lookups are done in constant time (See DefaultHash()).
+// Counts are always positive, an element whose count reaches 0 is dropped.
+type Counter struct {
+	// Returns whether two elements are equal
+	// Default implementation uses reflect.DeepEqual (==)
+	Equals func(a, b interface{}) bool
+
+	// Optional hash function, equal elements **MUST** have the same hash
+	// **Nil by default**
+	// Should be set before adding elements.
+	Hash func(elem interface{}) uint64
+
+	// entries in order of first insertion
+	entries []*CounterEntry
+	// hash -> entries, only used if Hash is set
+	buckets map[uint64][]*CounterEntry
+}
+
+// An element and how many times it was counted
+type CounterEntry struct {
+	Elem  interface{}
+	Count int
+}
+
+// Initialize a new empty counter
+func NewCounter() *Counter {
+	c := &Counter{}
+	c.Equals = func(a, b interface{}) bool { return reflect.DeepEqual(a, b) }
+	return c
+}
+
+// Initialize a new counter with the occurrences of the slice elements
+// The counter uses the slice Equals and Hash functions.
+func NewCounterFromSlice(s *Slice) *Counter {
+	c := NewCounter()
+	c.Equals = s.Equals
+	c.Hash = s.Hash
+	for _, e := range s.slice {
+		c.Add(e, 1)
+	}
+	return c
+}
+
+// Count the element n more times (in place)
+// A negative n is the same as Remove(elem, -n)
+// Return the counter pointer to allow method chaining.
+func (c *Counter) Add(elem interface{}, n int) *Counter {
+	if n < 0 {
+		return c.Remove(elem, -n)
+	}
+	if n == 0 {
+		return c
+	}
+	if entry := c.find(elem); entry != nil {
+		entry.Count += n
+		return c
+	}
+	entry := &CounterEntry{Elem: elem, Count: n}
+	c.entries = append(c.entries, entry)
+	if c.Hash != nil {
+		h := c.Hash(elem)
+		c.buckets[h] = append(c.buckets[h], entry)
+	}
+	return c
+}
+
+// Count all the given elements once more (in place)
+// Return the counter pointer to allow method chaining.
+func (c *Counter) AddAll(elems ...interface{}) *Counter {
+	for _, elem := range elems {
+		c.Add(elem, 1)
+	}
+	return c
+}
+
+// Clear (empty) the counter
+// Return the counter pointer to allow method chaining.
+func (c *Counter) Clear() *Counter {
+	c.entries = nil
+	c.buckets = nil
+	return c
+}
+
+// Create and return a clone of this counter
+func (c *Counter) Clone() *Counter {
+	clone := c.empty()
+	for _, entry := range c.entries {
+		clone.Add(entry.Elem, entry.Count)
+	}
+	return clone
+}
+
+// Does the counter contain the element (at least once)
+func (c *Counter) Contains(elem interface{}) bool {
+	return c.find(elem) != nil
+}
+
+// How many times the element was counted (0 if never)
+func (c *Counter) Count(elem interface{}) int {
+	if entry := c.find(elem); entry != nil {
+		return entry.Count
+	}
+	return 0
+}
+
+// Apply the function to all the distinct elements (in order of first insertion)
+// If the function returns true (stop), iteration will stop
+func (c *Counter) Each(f func(elem interface{}, count int) (stop bool)) {
+	for _, entry := range c.entries {
+		if f(entry.Elem, entry.Count) {
+			return
+		}
+	}
+}
+
+// Returns a new Slice with each element repeated as many times as it's counted
+func (c *Counter) Elements() *Slice {
+	s := NewSlice()
+	s.Equals = c.Equals
+	for _, entry := range c.entries {
+		s.Fill(entry.Elem, entry.Count)
+	}
+	return s
+}
+
+// Returns a new counter with the minimum count of each element (intersection)
+// Elements not present in both counters are dropped.
+func (c *Counter) Intersect(other *Counter) *Counter {
+	result := c.empty()
+	for _, entry := range c.entries {
+		n := other.Count(entry.Elem)
+		if entry.Count < n {
+			n = entry.Count
+		}
+		result.Add(entry.Elem, n)
+	}
+	return result
+}
+
+// Is this counter empty
+func (c *Counter) IsEmpty() bool {
+	return len(c.entries) == 0
+}
+
+// Returns a new Slice of the distinct elements (in order of first insertion)
+func (c *Counter) Keys() *Slice {
+	s := NewSlice()
+	s.Equals = c.Equals
+	for _, entry := range c.entries {
+		s.slice = append(s.slice, entry.Elem)
+	}
+	return s
+}
+
+// Number of distinct elements
+func (c *Counter) Len() int {
+	return len(c.entries)
+}
+
+// Returns a new counter with the counts of other subtracted from this one
+// Elements whose count drops to 0 or less are dropped.
+func (c *Counter) Minus(other *Counter) *Counter {
+	result := c.Clone()
+	for _, entry := range other.entries {
+		result.Remove(entry.Elem, entry.Count)
+	}
+	return result
+}
+
+// Returns the k most common elements as a Slice of CounterEntry, most common first
+// Elements with the same count are in order of first insertion.
+// if k <= 0 all the elements are returned.
+func (c *Counter) MostCommon(k int) *Slice {
+	entries := make([]*CounterEntry, len(c.entries))
+	copy(entries, c.entries)
+	sort.SliceStable(entries, func(a, b int) bool {
+		return entries[a].Count > entries[b].Count
+	})
+	if k <= 0 || k > len(entries) {
+		k = len(entries)
+	}
+	s := NewSlice()
+	for _, entry := range entries[:k] {
+		s.slice = append(s.slice, *entry)
+	}
+	return s
+}
+
+// Returns a new counter with the counts of both counters added up
+func (c *Counter) Plus(other *Counter) *Counter {
+	result := c.Clone()
+	for _, entry := range other.entries {
+		result.Add(entry.Elem, entry.Count)
+	}
+	return result
+}
+
+// Count the element n less times (in place), dropping it if the count reaches 0
+// Return the counter pointer to allow method chaining.
+func (c *Counter) Remove(elem interface{}, n int) *Counter {
+	entry := c.find(elem)
+	if entry == nil {
+		return c
+	}
+	entry.Count -= n
+	if entry.Count > 0 {
+		return c
+	}
+	for i, e := range c.entries {
+		if e == entry {
+			c.entries = append(c.entries[:i], c.entries[i+1:]...)
+			break
+		}
+	}
+	if c.Hash != nil {
+		h := c.Hash(elem)
+		bucket := c.buckets[h]
+		for i, e := range bucket {
+			if e == entry {
+				bucket = append(bucket[:i], bucket[i+1:]...)
+				break
+			}
+		}
+		if len(bucket) == 0 {
+			delete(c.buckets, h)
+		} else {
+			c.buckets[h] = bucket
+		}
+	}
+	return c
+}
+
+// Forget all the counts (impl Clearable)
+func (c *Counter) Reset() {
+	c.Clear()
+}
+
+// impl String interface
+func (c *Counter) String() string {
+	var buf bytes.Buffer
+	for i, entry := range c.entries {
+		if i != 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString(fmt.Sprintf("%v:%d", entry.Elem, entry.Count))
+	}
+	return fmt.Sprintf("Counter[%d] [%s]", len(c.entries), buf.String())
+}
+
+// Total of all the counts
+func (c *Counter) Total() int {
+	total := 0
+	for _, entry := range c.entries {
+		total += entry.Count
+	}
+	return total
+}
+
+// Returns a new counter with the maximum count of each element (union)
+func (c *Counter) Union(other *Counter) *Counter {
+	result := c.Clone()
+	for _, entry := range other.entries {
+		if n := entry.Count - result.Count(entry.Elem); n > 0 {
+			result.Add(entry.Elem, n)
+		}
+	}
+	return result
+}
+
+// New empty counter using the same Equals and Hash functions
+func (c *Counter) empty() *Counter {
+	return &Counter{Equals: c.Equals, Hash: c.Hash}
+}
+
+// Find the entry of the given element, nil if none
+func (c *Counter) find(elem interface{}) *CounterEntry {
+	if c.Hash == nil {
+		for _, entry := range c.entries {
+			if c.Equals(entry.Elem, elem) {
+				return entry
+			}
+		}
+		return nil
+	}
+	if c.buckets == nil {
+		// first use (or Hash was set after elements where added)
+		c.buckets = map[uint64][]*CounterEntry{}
+		for _, entry := range c.entries {
+			h := c.Hash(entry.Elem)
+			c.buckets[h] = append(c.buckets[h], entry)
+		}
+	}
+	for _, entry := range c.buckets[c.Hash(elem)] {
+		if c.Equals(entry.Elem, elem) {
+			return entry
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestCounter(t *testing.T) {
+
+	convey.Convey("Count", t, func() {
+		c := NewCounter().AddAll("a", "b", "a", "c", "a", "b")
+		convey.So(c.Count("a"), convey.ShouldEqual, 3)
+		convey.So(c.Count("z"), convey.ShouldEqual, 0)
+		convey.So(c.Len(), convey.ShouldEqual, 3)
+		convey.So(c.Total(), convey.ShouldEqual, 6)
+		convey.So(c.String(), convey.ShouldEqual, "Counter[3] [a:3 b:2 c:1]")
+		c.Add("c", 4)
+		convey.So(c.Count("c"), convey.ShouldEqual, 5)
+		c.Remove("b", 1)
+		convey.So(c.Count("b"), convey.ShouldEqual, 1)
+		c.Add("b", -5)
+		convey.So(c.Contains("b"), convey.ShouldBeFalse)
+		convey.So(c.Keys().Join(""), convey.ShouldEqual, "ac")
+		convey.So(c.Elements().Len(), convey.ShouldEqual, 8)
+		c.Clear()
+		convey.So(c.IsEmpty(), convey.ShouldBeTrue)
+	})
+
+	convey.Convey("MostCommon", t, func() {
+		c := NewCounterFromSlice(NewSlice().AppendAll(3, 1, 2, 2, 1, 5, 1))
+		common := c.MostCommon(2)
+		convey.So(common.Len(), convey.ShouldEqual, 2)
+		var entry CounterEntry
+		common.Get(0, &entry)
+		convey.So(entry, convey.ShouldResemble, CounterEntry{Elem: 1, Count: 3})
+		common.Get(1, &entry)
+		convey.So(entry, convey.ShouldResemble, CounterEntry{Elem: 2, Count: 2})
+		// ties are kept in insertion order
+		all := c.MostCommon(0)
+		convey.So(all.Len(), convey.ShouldEqual, 4)
+		all.Get(2, &entry)
+		convey.So(entry.Elem, convey.ShouldEqual, 3)
+	})
+
+	convey.Convey("Arithmetic", t, func() {
+		a := NewCounter().Add("x", 3).Add("y", 1)
+		b := NewCounter().Add("x", 1).Add("y", 2).Add("z", 1)
+		convey.So(a.Plus(b).String(), convey.ShouldEqual, "Counter[3] [x:4 y:3 z:1]")
+		convey.So(a.Minus(b).String(), convey.ShouldEqual, "Counter[1] [x:2]")
+		convey.So(a.Intersect(b).String(), convey.ShouldEqual, "Counter[2] [x:1 y:1]")
+		convey.So(a.Union(b).String(), convey.ShouldEqual, "Counter[3] [x:3 y:2 z:1]")
+		// operands are left untouched
+		convey.So(a.String(), convey.ShouldEqual, "Counter[2] [x:3 y:1]")
+	})
+
+	convey.Convey("Hash", t, func() {
+		c := NewCounter()
+		c.Hash = DefaultHash
+		for i := 0; i != 100; i++ {
+			c.Add(thingy{val: i % 10}, 1)
+		}
+		convey.So(c.Len(), convey.ShouldEqual, 10)
+		convey.So(c.Count(thingy{val: 3}), convey.ShouldEqual, 10)
+		c.Remove(thingy{val: 3}, 10)
+		convey.So(c.Count(thingy{val: 3}), convey.ShouldEqual, 0)
+		convey.So(c.Len(), convey.ShouldEqual, 9)
+		c.Add(thingy{val: 3}, 2)
+		convey.So(c.Clone().Count(thingy{val: 3}), convey.ShouldEqual, 2)
+	})
+}
@@ -0,0 +1,350 @@
+// History: Oct 18 26 agent Creation
+
+// Package gollectionsvet provides a go/analysis analyzer catching misuses of
+// the gollections pointer target APIs (Get, To ...) that would otherwise only
+// be found at runtime.
+//
+// It reports:
+//   - non pointer arguments passed as a ptr target (ie: s.Get(0, val))
+//   - To/ToRange targets that are not a pointer to a slice
+//   - targets whose type does not match the (single) type of the values put in
+//     the slice, (ie: s.To(&[]string{}) on a slice only ever given ints)
+//   - Min/Max/Less/sort usage on slices created by NewSlice() whose Compare
+//     function is never assigned within the package
+//
+// The last two checks are skipped for slices that escape the function creating
+// them (passed to a call, assigned or returned) since they might be changed elsewhere.
+//
+// Use it with go vet:
+//
+//	go install github.com/tcolar/gollections/cmd/gollections-vet
+//	go vet -vettool=$(which gollections-vet) ./...
+package gollectionsvet
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Import path of the gollections package
+const gollectionsPath = "github.com/tcolar/gollections"
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "gollections",
+	Doc:      "check for misuses of the gollections pointer target APIs",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// Slice methods storing values in the slice, and the index of their first value argument
+var storeMethods = map[string]int{
+	"Append":    0,
+	"AppendAll": 0,
+	"Fill":      0,
+	"Insert":    1,
+	"InsertAll": 1,
+	"Push":      0,
+	"Set":       1,
+}
+
+// Slice methods requiring Compare to be set
+var compareMethods = map[string]bool{
+	"Less": true,
+	"Max":  true,
+	"Min":  true,
+}
+
+// sort package functions using Less (their first argument)
+var sortFuncs = map[string]bool{
+	"IsSorted": true,
+	"Sort":     true,
+	"Stable":   true,
+}
+
+// Per package state of the analysis
+type checker struct {
+	pass *analysis.Pass
+	// Slice variables created by NewSlice()
+	created map[types.Object]bool
+	// Slice variables (or fields) whose Compare function is assigned
+	compared map[types.Object]bool
+	// Slice variables passed to a call, assigned or returned
+	escaped map[types.Object]bool
+	// Types of the values stored in each slice variable
+	stored map[types.Object][]types.Type
+	// Calls to check once the whole package was scanned
+	compareCalls []compareCall
+	targetCalls  []*ast.CallExpr
+}
+
+type compareCall struct {
+	pos  token.Pos
+	obj  types.Object
+	name string
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	c := &checker{
+		pass:     pass,
+		created:  map[types.Object]bool{},
+		compared: map[types.Object]bool{},
+		escaped:  map[types.Object]bool{},
+		stored:   map[types.Object][]types.Type{},
+	}
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodes := []ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.CallExpr)(nil),
+		(*ast.CompositeLit)(nil),
+		(*ast.ReturnStmt)(nil),
+		(*ast.ValueSpec)(nil),
+	}
+	insp.Preorder(nodes, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			c.assign(n.Lhs, n.Rhs)
+		case *ast.ValueSpec:
+			lhs := make([]ast.Expr, len(n.Names))
+			for i, name := range n.Names {
+				lhs[i] = name
+			}
+			c.assign(lhs, n.Values)
+		case *ast.CallExpr:
+			c.call(n)
+		case *ast.CompositeLit:
+			for _, elt := range n.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					elt = kv.Value
+				}
+				c.escape(elt)
+			}
+		case *ast.ReturnStmt:
+			for _, result := range n.Results {
+				c.escape(result)
+			}
+		}
+	})
+	for _, call := range c.targetCalls {
+		c.checkTargetType(call)
+	}
+	for _, call := range c.compareCalls {
+		if c.created[call.obj] && !c.escaped[call.obj] && !c.compared[call.obj] {
+			pass.Reportf(call.pos, "%s used on slice %s whose Compare function is never set", call.name, call.obj.Name())
+		}
+	}
+	return nil, nil
+}
+
+// Record slices created by NewSlice() and Compare assignments
+func (c *checker) assign(lhs, rhs []ast.Expr) {
+	for i, l := range lhs {
+		if sel, ok := l.(*ast.SelectorExpr); ok && sel.Sel.Name == "Compare" && isSlice(c.typeOf(sel.X)) {
+			if obj := c.objectOf(sel.X); obj != nil {
+				c.compared[obj] = true
+			}
+		}
+		if len(lhs) != len(rhs) {
+			continue
+		}
+		c.escape(rhs[i])
+		if call, ok := rhs[i].(*ast.CallExpr); ok && c.isFunc(call.Fun, gollectionsPath, "NewSlice") {
+			if obj := c.objectOf(l); obj != nil {
+				c.created[obj] = true
+			}
+		}
+	}
+}
+
+func (c *checker) call(call *ast.CallExpr) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !c.isFunc(call.Fun, "sort", "") {
+		for _, arg := range call.Args {
+			c.escape(arg)
+		}
+	}
+	if !ok {
+		return
+	}
+	if c.isFunc(call.Fun, "sort", "") && sortFuncs[sel.Sel.Name] && len(call.Args) == 1 {
+		if obj := c.objectOf(call.Args[0]); obj != nil && isSlice(c.typeOf(call.Args[0])) {
+			c.compareCalls = append(c.compareCalls, compareCall{call.Pos(), obj, "sort." + sel.Sel.Name})
+		}
+		return
+	}
+	fn, ok := c.pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != gollectionsPath {
+		return
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Recv() == nil {
+		return
+	}
+	// Pointer targets
+	for i := 0; i < sig.Params().Len() && i < len(call.Args); i++ {
+		param := sig.Params().At(i)
+		if param.Name() == "ptr" && types.IsInterface(param.Type()) {
+			c.checkPtr(call, call.Args[i])
+		}
+	}
+	if !isSlice(sig.Recv().Type()) {
+		return
+	}
+	obj := c.objectOf(sel.X)
+	name := sel.Sel.Name
+	switch {
+	case compareMethods[name]:
+		if obj != nil {
+			c.compareCalls = append(c.compareCalls, compareCall{call.Pos(), obj, name})
+		}
+	case name == "To" || name == "ToRange" || name == "Get" || name == "First" ||
+		name == "Last" || name == "Peek" || name == "Pop":
+		c.targetCalls = append(c.targetCalls, call)
+	}
+	if first, ok := storeMethods[name]; ok && obj != nil {
+		args := call.Args[first:]
+		if name == "Fill" {
+			args = args[:1]
+		}
+		if call.Ellipsis.IsValid() {
+			return
+		}
+		for _, arg := range args {
+			if t := c.typeOf(arg); t != nil {
+				c.stored[obj] = append(c.stored[obj], types.Default(t))
+			}
+		}
+	}
+}
+
+// Report ptr arguments that are not pointers, or To targets that are not
+// pointers to slices
+func (c *checker) checkPtr(call *ast.CallExpr, arg ast.Expr) {
+	t := c.typeOf(arg)
+	if t == nil || types.IsInterface(t) {
+		// Can't tell statically
+		return
+	}
+	ptr, ok := t.Underlying().(*types.Pointer)
+	if !ok {
+		c.pass.Reportf(arg.Pos(), "non pointer argument %s passed as a target to %s", types.ExprString(arg), calledName(call))
+		return
+	}
+	name := calledName(call)
+	if name == "To" || name == "ToRange" {
+		if _, ok := ptr.Elem().Underlying().(*types.Slice); !ok {
+			c.pass.Reportf(arg.Pos(), "%s target %s should be a pointer to a slice", name, types.ExprString(arg))
+		}
+	}
+}
+
+// Report targets whose type can't hold the values stored in the slice
+// Only done when all the stored values have the same (non interface) type.
+func (c *checker) checkTargetType(call *ast.CallExpr) {
+	sel := call.Fun.(*ast.SelectorExpr)
+	obj := c.objectOf(sel.X)
+	if obj == nil || !c.created[obj] || c.escaped[obj] || len(c.stored[obj]) == 0 {
+		return
+	}
+	stored := c.stored[obj][0]
+	for _, t := range c.stored[obj][1:] {
+		if !types.Identical(t, stored) {
+			return
+		}
+	}
+	if types.IsInterface(stored) {
+		return
+	}
+	t := c.typeOf(call.Args[len(call.Args)-1])
+	if t == nil {
+		return
+	}
+	ptr, ok := t.Underlying().(*types.Pointer)
+	if !ok {
+		return
+	}
+	target := ptr.Elem()
+	if sel.Sel.Name == "To" || sel.Sel.Name == "ToRange" {
+		slice, ok := target.Underlying().(*types.Slice)
+		if !ok {
+			return
+		}
+		target = slice.Elem()
+	}
+	if !types.AssignableTo(stored, target) {
+		c.pass.Reportf(call.Args[len(call.Args)-1].Pos(), "%s target of type %s can't hold the %s values of slice %s",
+			sel.Sel.Name, types.TypeString(t, nil), types.TypeString(stored, nil), obj.Name())
+	}
+}
+
+// Record the slice variable expr refers to (if any) as escaped
+func (c *checker) escape(expr ast.Expr) {
+	if !isSlice(c.typeOf(expr)) {
+		return
+	}
+	if obj := c.objectOf(expr); obj != nil {
+		c.escaped[obj] = true
+	}
+}
+
+// Is expr the given package function (any function of the package if name is "")
+func (c *checker) isFunc(expr ast.Expr, pkg, name string) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	fn, ok := c.pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != pkg {
+		return false
+	}
+	return name == "" || fn.Name() == name
+}
+
+// Variable (or field) an expression refers to, nil if it's not a simple one
+func (c *checker) objectOf(expr ast.Expr) types.Object {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return c.pass.TypesInfo.ObjectOf(e)
+	case *ast.SelectorExpr:
+		return c.pass.TypesInfo.ObjectOf(e.Sel)
+	case *ast.ParenExpr:
+		return c.objectOf(e.X)
+	case *ast.CallExpr:
+		// Chained calls: s.Append(1).Append(2)
+		if sel, ok := e.Fun.(*ast.SelectorExpr); ok {
+			if fn, ok := c.pass.TypesInfo.Uses[sel.Sel].(*types.Func); ok {
+				sig := fn.Type().(*types.Signature)
+				if sig.Recv() != nil && isSlice(sig.Recv().Type()) && sig.Results().Len() == 1 &&
+					isSlice(sig.Results().At(0).Type()) {
+					return c.objectOf(sel.X)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (c *checker) typeOf(expr ast.Expr) types.Type {
+	return c.pass.TypesInfo.TypeOf(expr)
+}
+
+func calledName(call *ast.CallExpr) string {
+	return call.Fun.(*ast.SelectorExpr).Sel.Name
+}
+
+// Is t gollections.Slice or a pointer to it
+func isSlice(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == gollectionsPath &&
+		named.Obj().Name() == "Slice"
+}
@@ -0,0 +1,13 @@
+// History: Oct 18 26 agent Creation
+
+package gollectionsvet
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "a")
+}
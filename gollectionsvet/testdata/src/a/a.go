@@ -0,0 +1,89 @@
+package a
+
+import (
+	"sort"
+
+	"github.com/tcolar/gollections"
+)
+
+func pointers(m *gollections.Map, target interface{}) {
+	s := gollections.NewSlice()
+	s.Append(1)
+	var val int
+	s.Get(0, &val)
+	s.Get(0, val) // want `non pointer argument val passed as a target to Get`
+	s.First(val)  // want `non pointer argument val passed as a target to First`
+	s.Last(val)   // want `non pointer argument val passed as a target to Last`
+	s.Pop(val)    // want `non pointer argument val passed as a target to Pop`
+	s.Peek(val)   // want `non pointer argument val passed as a target to Peek`
+	s.Get(0, target)
+	m.Get("a", val) // want `non pointer argument val passed as a target to Get`
+	m.Get("a", &val)
+}
+
+func toTargets() {
+	s := gollections.NewSlice()
+	s.AppendAll(1, 2, 3)
+	var ints []int
+	s.To(&ints)
+	s.To(ints)        // want `non pointer argument ints passed as a target to To`
+	s.To(&[]string{}) // want `To target of type \*\[\]string can't hold the int values of slice s`
+	var i int
+	s.ToRange(0, 1, &i) // want `ToRange target &i should be a pointer to a slice`
+	var str string
+	s.Get(0, &str) // want `Get target of type \*string can't hold the int values of slice s`
+
+	mixed := gollections.NewSlice()
+	mixed.Append(1).Append("a")
+	mixed.To(&[]string{})
+	var any []interface{}
+	s.To(&any)
+}
+
+func compare() {
+	s := gollections.NewSlice()
+	var val int
+	s.Min(&val)      // want `Min used on slice s whose Compare function is never set`
+	s.Max(&val)      // want `Max used on slice s whose Compare function is never set`
+	_ = s.Less(0, 1) // want `Less used on slice s whose Compare function is never set`
+	sort.Sort(s)     // want `sort.Sort used on slice s whose Compare function is never set`
+
+	sorted := gollections.NewSlice()
+	sorted.Compare = func(a, b interface{}) int { return a.(int) - b.(int) }
+	sorted.Min(&val)
+	sort.Sort(sorted)
+}
+
+func setCmp(s *gollections.Slice) {
+	s.Compare = func(a, b interface{}) int { return a.(int) - b.(int) }
+}
+
+type holder struct {
+	s *gollections.Slice
+}
+
+func escaped() *gollections.Slice {
+	var val int
+	// Compare set by a helper
+	passed := gollections.NewSlice()
+	setCmp(passed)
+	passed.Min(&val)
+	// Compare might be set through the copies
+	assigned := gollections.NewSlice()
+	other := assigned
+	assigned.Max(&val)
+	field := gollections.NewSlice()
+	h := holder{s: field}
+	sort.Sort(field)
+	returned := gollections.NewSlice()
+	returned.Min(&val)
+	_, _ = other, h
+	return returned
+}
+
+func unknown(s *gollections.Slice) {
+	var val int
+	// s might have a Compare function set elsewhere
+	s.Min(&val)
+	sort.Stable(s)
+}
@@ -0,0 +1,31 @@
+// Stub of the gollections API used by the analyzer tests
+package gollections
+
+type Slice struct {
+	Equals  func(a, b interface{}) bool
+	Compare func(a, b interface{}) int
+}
+
+func NewSlice() *Slice { return &Slice{} }
+
+func (s *Slice) Append(elem interface{}) *Slice          { return s }
+func (s *Slice) AppendAll(elems ...interface{}) *Slice   { return s }
+func (s *Slice) Fill(elem interface{}, count int) *Slice { return s }
+func (s *Slice) First(ptr interface{})                   {}
+func (s *Slice) Get(idx int, ptr interface{})            {}
+func (s *Slice) Insert(idx int, elem interface{}) *Slice { return s }
+func (s *Slice) Last(ptr interface{})                    {}
+func (s *Slice) Len() int                                { return 0 }
+func (s *Slice) Less(a, b int) bool                      { return false }
+func (s *Slice) Max(ptr interface{})                     {}
+func (s *Slice) Min(ptr interface{})                     {}
+func (s *Slice) Peek(ptr interface{})                    {}
+func (s *Slice) Pop(ptr interface{})                     {}
+func (s *Slice) Set(idx int, elem interface{}) *Slice    { return s }
+func (s *Slice) Swap(a, b int)                           {}
+func (s *Slice) To(ptr interface{})                      {}
+func (s *Slice) ToRange(from, to int, ptr interface{})   {}
+
+type Map struct{}
+
+func (m *Map) Get(key interface{}, ptr interface{}) (found bool) { return false }
@@ -0,0 +1,20 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// Default hash function that can be used by the collections supporting a Hash
+// function (ie: Counter), in combination with the default Equals (reflect.DeepEqual).
+// It hashes the type and "%v" representation of the element, so it's generic
+// but not particularly fast, a custom Hash function is preferable when performance matters.
+// Note: Elements holding nested pointers hash by address, so equal (DeepEqual)
+// elements might not hash the same in that case.
+func DefaultHash(elem interface{}) uint64 {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%T:%v", elem, elem)
+	return h.Sum64()
+}
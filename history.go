@@ -0,0 +1,258 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"errors"
+)
+
+// Undo / Redo history of the changes made to a Slice
+// It subscribes to the slice changes (See Slice.Subscribe) and records them so
+// they can be reverted (by applying the inverse operations) and re-applied.
+// Note: Changes made directly to the raw slice (See Slice.Slice()) are not seen,
+// and undo/redo can't be done properly if a before change hook vetoes them.
+type History struct {
+	s *Slice
+	// Maximum number of undo entries kept (0 for unbounded)
+	max  int
+	undo []*historyEntry
+	redo []*historyEntry
+	// entry being recorded by Group(), and how deep the groups are nested
+	group      *historyEntry
+	groupDepth int
+	// set while undoing / redoing so those changes aren't recorded
+	replaying bool
+	// last entry id, and the state before the oldest undo entry
+	lastId int
+	baseId Checkpoint
+	stop   func()
+}
+
+// Identifies a state of a History (See Checkpoint() and RevertTo())
+type Checkpoint int
+
+// Error returned by RevertTo if the state was discarded (history trimmed or
+// changes made after undoing past it)
+var ErrInvalidCheckpoint = errors.New("Checkpoint is no longer in the history")
+
+// Changes undone / redone as one
+type historyEntry struct {
+	id     Checkpoint
+	events []SliceEvent
+}
+
+// Initialize a new history recording the changes made to s
+// max is the maximum number of undo entries kept (0 for unbounded)
+func NewHistory(s *Slice, max int) *History {
+	h := &History{s: s, max: max}
+	h.stop = s.Subscribe(h.record)
+	return h
+}
+
+// Can a change be undone
+func (h *History) CanUndo() bool {
+	return len(h.undo) > 0
+}
+
+// Can a change be redone
+func (h *History) CanRedo() bool {
+	return len(h.redo) > 0
+}
+
+// Returns the current state, that can be restored with RevertTo()
+func (h *History) Checkpoint() Checkpoint {
+	if len(h.undo) == 0 {
+		return h.baseId
+	}
+	return h.undo[len(h.undo)-1].id
+}
+
+// Forget all the recorded changes
+// Return the history pointer to allow method chaining.
+func (h *History) Clear() *History {
+	h.baseId = h.Checkpoint()
+	h.undo, h.redo = nil, nil
+	return h
+}
+
+// Stop recording the changes made to the slice
+func (h *History) Close() {
+	h.stop()
+}
+
+// Record all the changes made by f as a single undo entry
+// Groups can be nested, in which case they are all merged into the outermost one.
+// Return the history pointer to allow method chaining.
+func (h *History) Group(f func()) *History {
+	if h.groupDepth == 0 {
+		h.group = &historyEntry{}
+	}
+	h.groupDepth++
+	defer func() {
+		h.groupDepth--
+		if h.groupDepth == 0 {
+			if len(h.group.events) > 0 {
+				h.push(h.group)
+			}
+			h.group = nil
+		}
+	}()
+	f()
+	return h
+}
+
+// Redo the last undone entry, returns false if there was none
+func (h *History) Redo() bool {
+	if len(h.redo) == 0 {
+		return false
+	}
+	entry := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	h.replay(func() {
+		for _, event := range entry.events {
+			h.apply(event)
+		}
+	})
+	h.undo = append(h.undo, entry)
+	return true
+}
+
+// Number of entries that can be redone
+func (h *History) RedoLen() int {
+	return len(h.redo)
+}
+
+// Undo or redo changes until the state is the checkpoint one
+func (h *History) RevertTo(checkpoint Checkpoint) error {
+	if !h.reachable(checkpoint) {
+		return ErrInvalidCheckpoint
+	}
+	for h.Checkpoint() != checkpoint {
+		if h.isUndone(checkpoint) {
+			h.Redo()
+		} else {
+			h.Undo()
+		}
+	}
+	return nil
+}
+
+// The tracked slice
+func (h *History) Slice() *Slice {
+	return h.s
+}
+
+// Undo the last entry, returns false if there was none
+func (h *History) Undo() bool {
+	if len(h.undo) == 0 {
+		return false
+	}
+	entry := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	h.replay(func() {
+		for i := len(entry.events) - 1; i >= 0; i-- {
+			h.revert(entry.events[i])
+		}
+	})
+	h.redo = append(h.redo, entry)
+	return true
+}
+
+// Number of entries that can be undone
+func (h *History) UndoLen() int {
+	return len(h.undo)
+}
+
+// Apply an event (again)
+func (h *History) apply(event SliceEvent) {
+	switch e := event.(type) {
+	case SliceInserted:
+		h.s.insert(e.Index, e.Elems)
+	case SliceRemoved:
+		h.s.remove(e.Index, len(e.Elems))
+	case SliceSet:
+		h.s.set(e.Index, e.New)
+	case SliceCleared:
+		h.s.clear()
+	case SliceReordered:
+		h.s.reorder(e.Index, e.Perm)
+	case SliceSwapped:
+		h.s.swap(e.A, e.B)
+	}
+}
+
+// Is the checkpoint that of an undone entry
+func (h *History) isUndone(checkpoint Checkpoint) bool {
+	for _, entry := range h.redo {
+		if entry.id == checkpoint {
+			return true
+		}
+	}
+	return false
+}
+
+// Add an entry to the undo list
+func (h *History) push(entry *historyEntry) {
+	h.lastId++
+	entry.id = Checkpoint(h.lastId)
+	h.undo = append(h.undo, entry)
+	h.redo = nil
+	if h.max > 0 && len(h.undo) > h.max {
+		h.baseId = h.undo[0].id
+		h.undo = h.undo[1:]
+	}
+}
+
+// Can the checkpoint be reached with undo / redo
+func (h *History) reachable(checkpoint Checkpoint) bool {
+	if checkpoint == h.baseId || h.isUndone(checkpoint) {
+		return true
+	}
+	for _, entry := range h.undo {
+		if entry.id == checkpoint {
+			return true
+		}
+	}
+	return false
+}
+
+// Slice change listener
+func (h *History) record(event SliceEvent) {
+	if h.replaying {
+		return
+	}
+	if h.group != nil {
+		h.group.events = append(h.group.events, event)
+		return
+	}
+	h.push(&historyEntry{events: []SliceEvent{event}})
+}
+
+// Run f without recording the changes
+func (h *History) replay(f func()) {
+	h.replaying = true
+	defer func() { h.replaying = false }()
+	f()
+}
+
+// Apply the inverse of an event
+func (h *History) revert(event SliceEvent) {
+	switch e := event.(type) {
+	case SliceInserted:
+		h.s.remove(e.Index, len(e.Elems))
+	case SliceRemoved:
+		h.s.insert(e.Index, e.Elems)
+	case SliceSet:
+		h.s.set(e.Index, e.Old)
+	case SliceCleared:
+		h.s.insert(0, e.Elems)
+	case SliceReordered:
+		inverse := make([]int, len(e.Perm))
+		for i, p := range e.Perm {
+			inverse[p] = i
+		}
+		h.s.reorder(e.Index, inverse)
+	case SliceSwapped:
+		h.s.swap(e.A, e.B)
+	}
+}
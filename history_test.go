@@ -0,0 +1,167 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"github.com/smartystreets/goconvey/convey"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestHistory(t *testing.T) {
+
+	convey.Convey("Undo & Redo", t, func() {
+		s := NewSlice().AppendAll(1, 2, 3)
+		h := NewHistory(s, 0)
+		convey.So(h.CanUndo(), convey.ShouldBeFalse)
+		convey.So(h.Undo(), convey.ShouldBeFalse)
+		s.Append(4)
+		s.Set(0, 9)
+		s.RemoveAt(1)
+		s.Reverse()
+		s.Clear()
+		convey.So(h.UndoLen(), convey.ShouldEqual, 5)
+		states := []string{"4,3,9", "9,3,4", "9,2,3,4", "1,2,3,4", "1,2,3"}
+		for _, state := range states {
+			convey.So(h.Undo(), convey.ShouldBeTrue)
+			convey.So(s.Join(","), convey.ShouldEqual, state)
+		}
+		convey.So(h.CanUndo(), convey.ShouldBeFalse)
+		convey.So(h.RedoLen(), convey.ShouldEqual, 5)
+		for i := len(states) - 2; i >= 0; i-- {
+			convey.So(h.Redo(), convey.ShouldBeTrue)
+			convey.So(s.Join(","), convey.ShouldEqual, states[i])
+		}
+		convey.So(h.Redo(), convey.ShouldBeTrue)
+		convey.So(s.IsEmpty(), convey.ShouldBeTrue)
+		convey.So(h.Redo(), convey.ShouldBeFalse)
+		// A new change discards the redo entries
+		h.Undo()
+		s.Append(5)
+		convey.So(h.CanRedo(), convey.ShouldBeFalse)
+		convey.So(h.Slice(), convey.ShouldEqual, s)
+	})
+
+	convey.Convey("Swaps", t, func() {
+		s := NewSlice().AppendAll(5, 3, 8, 1, 9, 2)
+		s.Compare = func(a, b interface{}) int { return naturalCompare(a, b) }
+		h := NewHistory(s, 0)
+		s.Swap(0, 5)
+		convey.So(s.Join(","), convey.ShouldEqual, "2,3,8,1,9,5")
+		convey.So(h.Undo(), convey.ShouldBeTrue)
+		convey.So(s.Join(","), convey.ShouldEqual, "5,3,8,1,9,2")
+		convey.So(h.Redo(), convey.ShouldBeTrue)
+		convey.So(s.Join(","), convey.ShouldEqual, "2,3,8,1,9,5")
+		sort.Sort(s)
+		convey.So(s.Join(","), convey.ShouldEqual, "1,2,3,5,8,9")
+		for h.Undo() {
+		}
+		convey.So(s.Join(","), convey.ShouldEqual, "5,3,8,1,9,2")
+	})
+
+	convey.Convey("Group", t, func() {
+		s := NewSlice().AppendAll(1, 2, 3)
+		h := NewHistory(s, 0)
+		h.Group(func() {
+			s.Append(4)
+			h.Group(func() {
+				s.RemoveAt(0).Set(0, 7)
+			})
+			s.Shuffle(rand.New(rand.NewSource(1)))
+		})
+		h.Group(func() {})
+		convey.So(h.UndoLen(), convey.ShouldEqual, 1)
+		h.Undo()
+		convey.So(s.Join(","), convey.ShouldEqual, "1,2,3")
+		h.Redo()
+		convey.So(s.ContainsAll(7, 3, 4), convey.ShouldBeTrue)
+		convey.So(s.Len(), convey.ShouldEqual, 3)
+	})
+
+	convey.Convey("Max entries", t, func() {
+		s := NewSlice()
+		h := NewHistory(s, 3)
+		for i := 0; i != 5; i++ {
+			s.Append(i)
+		}
+		convey.So(h.UndoLen(), convey.ShouldEqual, 3)
+		for h.Undo() {
+		}
+		convey.So(s.Join(","), convey.ShouldEqual, "0,1")
+	})
+
+	convey.Convey("Checkpoints", t, func() {
+		s := NewSlice().AppendAll(1, 2)
+		h := NewHistory(s, 4)
+		start := h.Checkpoint()
+		s.Append(3)
+		middle := h.Checkpoint()
+		s.Append(4).Append(5)
+		convey.So(h.RevertTo(middle), convey.ShouldBeNil)
+		convey.So(s.Join(","), convey.ShouldEqual, "1,2,3")
+		h.Redo()
+		h.Redo()
+		end := h.Checkpoint()
+		convey.So(h.RevertTo(start), convey.ShouldBeNil)
+		convey.So(s.Join(","), convey.ShouldEqual, "1,2")
+		convey.So(h.RevertTo(end), convey.ShouldBeNil)
+		convey.So(s.Join(","), convey.ShouldEqual, "1,2,3,4,5")
+		// branching discards the undone states
+		h.RevertTo(middle)
+		s.Append(6)
+		convey.So(h.RevertTo(end), convey.ShouldEqual, ErrInvalidCheckpoint)
+		// trimmed states are lost as well
+		s.Fill(0, 1).Fill(0, 1).Fill(0, 1)
+		convey.So(h.RevertTo(start), convey.ShouldEqual, ErrInvalidCheckpoint)
+		h.Clear()
+		convey.So(h.CanUndo(), convey.ShouldBeFalse)
+		cleared := h.Checkpoint()
+		s.RemoveAt(0)
+		convey.So(h.RevertTo(cleared), convey.ShouldBeNil)
+		convey.So(s.Len(), convey.ShouldEqual, 7)
+	})
+
+	convey.Convey("Random changes", t, func() {
+		rng := rand.New(rand.NewSource(8))
+		s := NewSlice().AppendAll(1, 2, 3, 4, 5)
+		h := NewHistory(s, 0)
+		states := []string{s.Join(",")}
+		for i := 0; i != 100; i++ {
+			h.Group(func() {
+				switch rng.Intn(6) {
+				case 0:
+					s.Insert(rng.Intn(s.Len()), i)
+				case 1:
+					s.RemoveRange(0, rng.Intn(s.Len()))
+				case 2:
+					s.Shuffle(rng)
+				case 3:
+					s.FilterInPlace(func(i int, e interface{}) bool { return rng.Intn(3) != 0 })
+				case 4:
+					s.MapInPlace(func(i int, e interface{}) interface{} { return e.(int) + 1 })
+				default:
+					s.Fill(i, 3)
+				}
+				if s.IsEmpty() {
+					s.Append(i)
+				}
+			})
+			if h.UndoLen() == len(states) { // unless nothing changed
+				states = append(states, s.Join(","))
+			}
+		}
+		convey.So(h.UndoLen(), convey.ShouldEqual, len(states)-1)
+		for i := len(states) - 2; i >= 0; i-- {
+			h.Undo()
+			convey.So(s.Join(","), convey.ShouldEqual, states[i])
+		}
+		for i := 1; i < len(states); i++ {
+			h.Redo()
+			convey.So(s.Join(","), convey.ShouldEqual, states[i])
+		}
+		h.Close()
+		s.Append(-1)
+		convey.So(h.UndoLen(), convey.ShouldEqual, len(states)-1)
+	})
+}
@@ -2,37 +2,236 @@
 
 package gollections
 
-import ()
+import (
+	"bytes"
+	"container/list"
+	"fmt"
+	"reflect"
+)
 
+// Custom "Generic" (Sorta) map
+// Keys are kept in insertion order, so iteration is predictable.
+// Note: Keys must be comparable (as for a builtin go map), otherwise it will panic
 type Map struct {
-	// TBD only slice done yet
-
-	/*
-	   Append(k, v)
-	   AppendAll ?
-	   addMap(Map)
-	   All -> same as map
-	   Any -> same as map
-	   caseInsensitive
-	   Clear
-	   ContainsKey
-	   Clone
-	   //CloneKeys ? clone only for some keys ?
-	   each
-	   eachWhile
-	   Find
-	   FindAll
-	   Get
-	   GetOrAdd (key, ptr, defaultVal)
-	   IsEmpty
-	   Join ??
-	   Keys
-	   Len
-	   Map -> new map applying function
-	   Reduce
-	   Remove
-	   Set
-	   String
-	   Vals
-	*/
+	// key -> list element holding the mapEntry
+	index map[interface{}]*list.Element
+	// entries in insertion order
+	entries *list.List
+}
+
+type mapEntry struct {
+	key interface{}
+	val interface{}
+}
+
+// Initialize a new empty map
+func NewMap() *Map {
+	return &Map{
+		index:   map[interface{}]*list.Element{},
+		entries: list.New(),
+	}
+}
+
+// Set all the entries of another Map into this map (in place)
+// Return the map pointer to allow method chaining.
+func (m *Map) AddMap(other *Map) *Map {
+	other.Each(func(key, val interface{}) bool {
+		m.Set(key, val)
+		return false
+	})
+	return m
+}
+
+// Return true if f returns true for all of the entries in the map.
+func (m *Map) All(f func(key, val interface{}) bool) bool {
+	for e := m.entries.Front(); e != nil; e = e.Next() {
+		entry := e.Value.(*mapEntry)
+		if !f(entry.key, entry.val) {
+			return false
+		}
+	}
+	return true
+}
+
+// Return true if f returns true for any(at least 1) of the entries in the map.
+func (m *Map) Any(f func(key, val interface{}) bool) bool {
+	for e := m.entries.Front(); e != nil; e = e.Next() {
+		entry := e.Value.(*mapEntry)
+		if f(entry.key, entry.val) {
+			return true
+		}
+	}
+	return false
+}
+
+// Clear (empty) the map
+// Return the map pointer to allow method chaining.
+func (m *Map) Clear() *Map {
+	m.index = map[interface{}]*list.Element{}
+	m.entries.Init()
+	return m
+}
+
+// Create and return a (shallow) clone of this map
+func (m *Map) Clone() *Map {
+	return NewMap().AddMap(m)
+}
+
+// Does the map contain the given key
+func (m *Map) ContainsKey(key interface{}) bool {
+	_, found := m.index[key]
+	return found
+}
+
+// Apply the function to the whole map (in insertion order)
+// If the function returns true (stop), iteration will stop
+func (m *Map) Each(f func(key, val interface{}) (stop bool)) {
+	for e := m.entries.Front(); e != nil; {
+		// Grab next first in case f removes the current entry
+		next := e.Next()
+		entry := e.Value.(*mapEntry)
+		if f(entry.key, entry.val) {
+			return
+		}
+		e = next
+	}
+}
+
+// Apply a function to find an entry in the map (iteratively)
+// Returns the key of the first entry for which the function returns true
+// found is false if there was no match.
+func (m *Map) Find(f func(key, val interface{}) bool) (key interface{}, found bool) {
+	for e := m.entries.Front(); e != nil; e = e.Next() {
+		entry := e.Value.(*mapEntry)
+		if f(entry.key, entry.val) {
+			return entry.key, true
+		}
+	}
+	return nil, false
+}
+
+// Apply a function to find all the entries for which the function returns true
+// Returns a new Map made of the matches.
+func (m *Map) FindAll(f func(key, val interface{}) bool) *Map {
+	results := NewMap()
+	for e := m.entries.Front(); e != nil; e = e.Next() {
+		entry := e.Value.(*mapEntry)
+		if f(entry.key, entry.val) {
+			results.Set(entry.key, entry.val)
+		}
+	}
+	return results
+}
+
+// Set value of ptr to the value associated with key
+// Return false (and leave ptr untouched) if the key is not in the map
+func (m *Map) Get(key interface{}, ptr interface{}) (found bool) {
+	val, found := m.get(key)
+	if found {
+		PtrToVal(ptr).Set(reflect.ValueOf(val))
+	}
+	return found
+}
+
+// Set value of ptr to the value associated with key
+// If the key is not in the map yet, defaultVal is first added to it.
+func (m *Map) GetOrAdd(key interface{}, ptr interface{}, defaultVal interface{}) {
+	if !m.ContainsKey(key) {
+		m.Set(key, defaultVal)
+	}
+	m.Get(key, ptr)
+}
+
+// Is this map empty
+func (m *Map) IsEmpty() bool {
+	return len(m.index) == 0
+}
+
+// Create a string by joining all the entries with the given separator
+// Each entry is formatted as key:val
+func (m *Map) Join(sep string) string {
+	var buf bytes.Buffer
+	for e := m.entries.Front(); e != nil; e = e.Next() {
+		if e != m.entries.Front() {
+			buf.WriteString(sep)
+		}
+		entry := e.Value.(*mapEntry)
+		buf.WriteString(fmt.Sprintf("%v:%v", entry.key, entry.val))
+	}
+	return buf.String()
+}
+
+// Returns a new Slice of all the keys (in insertion order)
+func (m *Map) Keys() *Slice {
+	keys := NewSlice()
+	for e := m.entries.Front(); e != nil; e = e.Next() {
+		keys.slice = append(keys.slice, e.Value.(*mapEntry).key)
+	}
+	return keys
+}
+
+// Number of entries in the map
+func (m *Map) Len() int {
+	return len(m.index)
+}
+
+// Reduce is used to iterate through every entry in the map to reduce the map
+// into a single value called the reduction.
+// Works like Slice.Reduce() but is given each key and value.
+func (m *Map) Reduce(startVal interface{}, f func(reduction interface{}, key, val interface{}) interface{}) interface{} {
+	reduction := startVal
+	for e := m.entries.Front(); e != nil; e = e.Next() {
+		entry := e.Value.(*mapEntry)
+		reduction = f(reduction, entry.key, entry.val)
+	}
+	return reduction
+}
+
+// Remove the entry with the given key (if any)
+// Return the map pointer to allow method chaining.
+func (m *Map) Remove(key interface{}) *Map {
+	if e, found := m.index[key]; found {
+		m.entries.Remove(e)
+		delete(m.index, key)
+	}
+	return m
+}
+
+// Empty the map (impl Clearable)
+func (m *Map) Reset() {
+	m.Clear()
+}
+
+// Set (add or replace) the value associated to key
+// A replaced entry keeps its original position.
+// Return the map pointer to allow method chaining.
+func (m *Map) Set(key, val interface{}) *Map {
+	if e, found := m.index[key]; found {
+		e.Value.(*mapEntry).val = val
+		return m
+	}
+	m.index[key] = m.entries.PushBack(&mapEntry{key: key, val: val})
+	return m
+}
+
+// impl String interface
+func (m *Map) String() string {
+	return fmt.Sprintf("Map[%d] [%s]", m.Len(), m.Join(" "))
+}
+
+// Returns a new Slice of all the values (in insertion order of their keys)
+func (m *Map) Vals() *Slice {
+	vals := NewSlice()
+	for e := m.entries.Front(); e != nil; e = e.Next() {
+		vals.slice = append(vals.slice, e.Value.(*mapEntry).val)
+	}
+	return vals
+}
+
+// Get the raw value associated with key
+func (m *Map) get(key interface{}) (interface{}, bool) {
+	if e, found := m.index[key]; found {
+		return e.Value.(*mapEntry).val, true
+	}
+	return nil, false
 }
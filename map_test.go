@@ -0,0 +1,79 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	m := testMap()
+	var result int
+
+	convey.Convey("Get & Set", t, func() {
+		convey.So(m.Get("b", &result), convey.ShouldBeTrue)
+		convey.So(result, convey.ShouldEqual, 2)
+		convey.So(m.Get("zz", &result), convey.ShouldBeFalse)
+		convey.So(result, convey.ShouldEqual, 2)
+		m.Set("b", 22)
+		m.Get("b", &result)
+		convey.So(result, convey.ShouldEqual, 22)
+		convey.So(m.Join(","), convey.ShouldEqual, "a:1,b:22,c:3")
+		m.GetOrAdd("d", &result, 4)
+		convey.So(result, convey.ShouldEqual, 4)
+		m.GetOrAdd("d", &result, 99)
+		convey.So(result, convey.ShouldEqual, 4)
+		convey.So(m.String(), convey.ShouldEqual, "Map[4] [a:1 b:22 c:3 d:4]")
+	})
+
+	convey.Convey("Keys & Vals", t, func() {
+		m := testMap()
+		convey.So(m.Keys().Join(""), convey.ShouldEqual, "abc")
+		convey.So(m.Vals().Join(""), convey.ShouldEqual, "123")
+		convey.So(m.ContainsKey("a"), convey.ShouldBeTrue)
+		convey.So(m.ContainsKey(1), convey.ShouldBeFalse)
+	})
+
+	convey.Convey("Remove & Clear", t, func() {
+		m := testMap()
+		m.Remove("a").Remove("zz")
+		convey.So(m.Len(), convey.ShouldEqual, 2)
+		convey.So(m.Keys().Join(""), convey.ShouldEqual, "bc")
+		m.Set("a", 1) // re-added at the end
+		convey.So(m.Keys().Join(""), convey.ShouldEqual, "bca")
+		c := m.Clone()
+		m.Clear()
+		convey.So(m.IsEmpty(), convey.ShouldBeTrue)
+		convey.So(c.Len(), convey.ShouldEqual, 3)
+	})
+
+	convey.Convey("Funcs", t, func() {
+		m := testMap()
+		convey.So(m.All(func(k, v interface{}) bool { return v.(int) > 0 }), convey.ShouldBeTrue)
+		convey.So(m.Any(func(k, v interface{}) bool { return v.(int) > 2 }), convey.ShouldBeTrue)
+		key, found := m.Find(func(k, v interface{}) bool { return v.(int) >= 2 })
+		convey.So(found, convey.ShouldBeTrue)
+		convey.So(key, convey.ShouldEqual, "b")
+		_, found = m.Find(func(k, v interface{}) bool { return v.(int) > 5 })
+		convey.So(found, convey.ShouldBeFalse)
+		odd := m.FindAll(func(k, v interface{}) bool { return v.(int)%2 == 1 })
+		convey.So(odd.Join(","), convey.ShouldEqual, "a:1,c:3")
+		sum := m.Reduce(0, func(reduction interface{}, k, v interface{}) interface{} {
+			return reduction.(int) + v.(int)
+		})
+		convey.So(sum, convey.ShouldEqual, 6)
+		// removing while iterating is allowed
+		m.Each(func(k, v interface{}) bool {
+			m.Remove(k)
+			return false
+		})
+		convey.So(m.IsEmpty(), convey.ShouldBeTrue)
+	})
+}
+
+// #################### TESTS DATA ############################################
+
+func testMap() *Map {
+	return NewMap().Set("a", 1).Set("b", 2).Set("c", 3)
+}
@@ -0,0 +1,207 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"fmt"
+)
+
+// Map where each key is associated to a collection (Slice) of values
+// The values of a key are either a plain list (NewMultiMap) or unique values
+// (by Equals, NewSetMultiMap)
+// Keys are kept in insertion order and must be comparable (see Map)
+type MultiMap struct {
+	// key -> *Slice of values
+	m *Map
+	// Whether values are unique per key (set like)
+	unique bool
+}
+
+// Initialize a new empty multimap where each key holds a list of values
+// (the same value can be added several times)
+func NewMultiMap() *MultiMap {
+	return &MultiMap{m: NewMap()}
+}
+
+// Initialize a new empty multimap where each key holds unique values
+// (adding a value already present for that key is a no-op)
+func NewSetMultiMap() *MultiMap {
+	return &MultiMap{m: NewMap(), unique: true}
+}
+
+// Clear (empty) the multimap
+// Return the multimap pointer to allow method chaining.
+func (m *MultiMap) Clear() *MultiMap {
+	m.m.Clear()
+	return m
+}
+
+// Does the multimap contain the given value for the given key
+func (m *MultiMap) ContainsEntry(key, val interface{}) bool {
+	vals, found := m.m.get(key)
+	return found && vals.(*Slice).Contains(val)
+}
+
+// Does the multimap contain the given key
+func (m *MultiMap) ContainsKey(key interface{}) bool {
+	return m.m.ContainsKey(key)
+}
+
+// Does the multimap contain the given value (under any key)
+func (m *MultiMap) ContainsValue(val interface{}) bool {
+	return m.m.Any(func(key, vals interface{}) bool {
+		return vals.(*Slice).Contains(val)
+	})
+}
+
+// Apply the function to every key/value entry
+// Keys in insertion order, then values in the order of their key collection
+// If the function returns true (stop), iteration will stop
+func (m *MultiMap) Each(f func(key, val interface{}) (stop bool)) {
+	m.m.Each(func(key, vals interface{}) bool {
+		for _, val := range vals.(*Slice).slice {
+			if f(key, val) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Apply the function to every key and its value collection
+// If the function returns true (stop), iteration will stop
+func (m *MultiMap) EachKey(f func(key interface{}, vals *Slice) (stop bool)) {
+	m.m.Each(func(key, vals interface{}) bool {
+		return f(key, vals.(*Slice))
+	})
+}
+
+// Returns the collection of values associated with the key
+// This is the live collection held by the multimap, so changes to it are
+// reflected in the multimap (for unique multimaps, care must be taken not to
+// introduce duplicates).
+// If the key is not present, a new empty Slice (not attached) is returned, so
+// no nil check is needed.
+func (m *MultiMap) Get(key interface{}) *Slice {
+	if vals, found := m.m.get(key); found {
+		return vals.(*Slice)
+	}
+	return NewSlice()
+}
+
+// Returns a new multimap where each value becomes a key to the keys it was
+// associated with. The inverse is of the same kind (list or unique).
+// Note: The values must be comparable for this to work (see Map)
+func (m *MultiMap) Inverse() *MultiMap {
+	inverse := &MultiMap{m: NewMap(), unique: m.unique}
+	m.Each(func(key, val interface{}) bool {
+		inverse.Put(val, key)
+		return false
+	})
+	return inverse
+}
+
+// Is this multimap empty
+func (m *MultiMap) IsEmpty() bool {
+	return m.m.IsEmpty()
+}
+
+// Whether the value collections hold unique values (NewSetMultiMap)
+func (m *MultiMap) IsUnique() bool {
+	return m.unique
+}
+
+// Number of distinct keys
+func (m *MultiMap) KeyCount() int {
+	return m.m.Len()
+}
+
+// Returns a new Slice of all the keys (in insertion order)
+func (m *MultiMap) Keys() *Slice {
+	return m.m.Keys()
+}
+
+// Number of key/value entries (same as ValueCount)
+func (m *MultiMap) Len() int {
+	return m.ValueCount()
+}
+
+// Associate a value with the key (in place)
+// Return the multimap pointer to allow method chaining.
+func (m *MultiMap) Put(key, val interface{}) *MultiMap {
+	vals, found := m.m.get(key)
+	if !found {
+		vals = NewSlice()
+		m.m.Set(key, vals)
+	}
+	s := vals.(*Slice)
+	if !m.unique || !s.Contains(val) {
+		s.Append(val)
+	}
+	return m
+}
+
+// Associate several values with the key (in place)
+// Return the multimap pointer to allow method chaining.
+func (m *MultiMap) PutAll(key interface{}, vals ...interface{}) *MultiMap {
+	for _, val := range vals {
+		m.Put(key, val)
+	}
+	return m
+}
+
+// Remove the key and all its values
+// Returns the values that where associated with the key (empty if none).
+func (m *MultiMap) RemoveKey(key interface{}) *Slice {
+	vals := m.Get(key)
+	m.m.Remove(key)
+	return vals
+}
+
+// Remove the (first) given value associated with the key (by equality)
+// The key is removed as well if it has no values left.
+// Return the multimap pointer to allow method chaining.
+func (m *MultiMap) RemoveValue(key, val interface{}) *MultiMap {
+	if vals, found := m.m.get(key); found {
+		s := vals.(*Slice)
+		s.RemoveElem(val)
+		if s.IsEmpty() {
+			m.m.Remove(key)
+		}
+	}
+	return m
+}
+
+// Remove every key along with its values (impl Clearable)
+func (m *MultiMap) Reset() {
+	m.Clear()
+}
+
+// impl String interface
+func (m *MultiMap) String() string {
+	return fmt.Sprintf("MultiMap[%d] [%s]", m.KeyCount(),
+		m.m.Reduce("", func(reduction interface{}, key, vals interface{}) interface{} {
+			str := reduction.(string)
+			if str != "" {
+				str += " "
+			}
+			return fmt.Sprintf("%s%v:[%s]", str, key, vals.(*Slice).Join(" "))
+		}))
+}
+
+// Total number of values (across all keys)
+func (m *MultiMap) ValueCount() int {
+	return m.m.Reduce(0, func(reduction interface{}, key, vals interface{}) interface{} {
+		return reduction.(int) + vals.(*Slice).Len()
+	}).(int)
+}
+
+// Returns a new Slice of all the values (see Each for ordering)
+func (m *MultiMap) Vals() *Slice {
+	vals := NewSlice()
+	m.m.Each(func(key, s interface{}) bool {
+		vals.AppendSlice(s.(*Slice))
+		return false
+	})
+	return vals
+}
@@ -0,0 +1,72 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestMultiMap(t *testing.T) {
+
+	convey.Convey("Put & Get", t, func() {
+		m := NewMultiMap()
+		m.Put("a", 1).Put("b", 2).PutAll("a", 3, 1)
+		convey.So(m.Get("a").Join(","), convey.ShouldEqual, "1,3,1")
+		convey.So(m.Get("zz").IsEmpty(), convey.ShouldBeTrue)
+		convey.So(m.KeyCount(), convey.ShouldEqual, 2)
+		convey.So(m.ValueCount(), convey.ShouldEqual, 4)
+		convey.So(m.Vals().Join(","), convey.ShouldEqual, "1,3,1,2")
+		convey.So(m.String(), convey.ShouldEqual, "MultiMap[2] [a:[1 3 1] b:[2]]")
+	})
+
+	convey.Convey("Unique", t, func() {
+		m := NewSetMultiMap()
+		m.PutAll("a", 1, 3, 1, 3).Put("b", 1)
+		convey.So(m.IsUnique(), convey.ShouldBeTrue)
+		convey.So(m.Get("a").Join(","), convey.ShouldEqual, "1,3")
+		convey.So(m.ValueCount(), convey.ShouldEqual, 3)
+	})
+
+	convey.Convey("Contains", t, func() {
+		m := NewMultiMap().PutAll("a", 1, 2).Put("b", 3)
+		convey.So(m.ContainsKey("a"), convey.ShouldBeTrue)
+		convey.So(m.ContainsKey("c"), convey.ShouldBeFalse)
+		convey.So(m.ContainsValue(3), convey.ShouldBeTrue)
+		convey.So(m.ContainsValue(4), convey.ShouldBeFalse)
+		convey.So(m.ContainsEntry("a", 2), convey.ShouldBeTrue)
+		convey.So(m.ContainsEntry("b", 2), convey.ShouldBeFalse)
+	})
+
+	convey.Convey("Remove", t, func() {
+		m := NewMultiMap().PutAll("a", 1, 2, 1).Put("b", 3)
+		m.RemoveValue("a", 1)
+		convey.So(m.Get("a").Join(","), convey.ShouldEqual, "2,1")
+		m.RemoveValue("b", 3)
+		convey.So(m.ContainsKey("b"), convey.ShouldBeFalse)
+		removed := m.RemoveKey("a")
+		convey.So(removed.Join(","), convey.ShouldEqual, "2,1")
+		convey.So(m.IsEmpty(), convey.ShouldBeTrue)
+		convey.So(m.RemoveKey("a").IsEmpty(), convey.ShouldBeTrue)
+	})
+
+	convey.Convey("Each & Inverse", t, func() {
+		m := NewMultiMap().PutAll("a", 1, 2).PutAll("b", 2, 3)
+		a := ""
+		m.Each(func(k, v interface{}) bool {
+			a += k.(string) + m.Get(k).Join("") + " "
+			return v == 2
+		})
+		convey.So(a, convey.ShouldEqual, "a12 a12 ")
+		inv := m.Inverse()
+		convey.So(inv.Keys().Join(","), convey.ShouldEqual, "1,2,3")
+		convey.So(inv.Get(2).Join(","), convey.ShouldEqual, "a,b")
+		convey.So(inv.IsUnique(), convey.ShouldBeFalse)
+		n := 0
+		m.EachKey(func(k interface{}, vals *Slice) bool {
+			n += vals.Len()
+			return false
+		})
+		convey.So(n, convey.ShouldEqual, 4)
+	})
+}
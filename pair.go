@@ -0,0 +1,39 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Immutable pair of "generic" values (as returned by Slice.Zip)
+type Pair struct {
+	first  interface{}
+	second interface{}
+}
+
+// Initialize a new pair
+func NewPair(first, second interface{}) *Pair {
+	return &Pair{first: first, second: second}
+}
+
+// Set value of ptr to the first value of the pair
+func (p *Pair) First(ptr interface{}) {
+	PtrToVal(ptr).Set(reflect.ValueOf(p.first))
+}
+
+// Set value of ptr to the second value of the pair
+func (p *Pair) Second(ptr interface{}) {
+	PtrToVal(ptr).Set(reflect.ValueOf(p.second))
+}
+
+// Returns both values of the pair
+func (p *Pair) Values() (first, second interface{}) {
+	return p.first, p.second
+}
+
+// impl String interface
+func (p *Pair) String() string {
+	return fmt.Sprintf("(%v, %v)", p.first, p.second)
+}
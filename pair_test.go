@@ -0,0 +1,26 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestPair(t *testing.T) {
+	var name string
+	var age int
+
+	convey.Convey("Pair", t, func() {
+		p := NewPair("joe", 42)
+		p.First(&name)
+		p.Second(&age)
+		convey.So(name, convey.ShouldEqual, "joe")
+		convey.So(age, convey.ShouldEqual, 42)
+		a, b := p.Values()
+		convey.So(a, convey.ShouldEqual, "joe")
+		convey.So(b, convey.ShouldEqual, 42)
+		convey.So(p.String(), convey.ShouldEqual, "(joe, 42)")
+		convey.So(func() { p.First(&age) }, convey.ShouldPanic)
+	})
+}
@@ -0,0 +1,497 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"bytes"
+	"fmt"
+	"math/bits"
+	"reflect"
+)
+
+// Immutable (persistent) map, implemented as a hash array mapped trie (HAMT)
+// Every "modification" (Set, Remove) returns a new version of the map sharing
+// most of its structure with the previous one, which is left untouched.
+// Thanks to this sharing, comparing (EqualTo) or diffing (Diff) two versions
+// only needs to look at the parts that differ.
+// Iteration order is unspecified (by hash) but stable for a given content.
+type PersistentMap struct {
+
+	// Returns whether two keys (or two values, when comparing maps) are equal
+	// Default implementation uses reflect.DeepEqual (==)
+	// Should be set on the empty map, versions derived from it inherit it.
+	Equals func(a, b interface{}) bool
+
+	// Hash function of the keys, equal keys **MUST** have the same hash
+	// Default implementation is DefaultHash()
+	// Should be set on the empty map, versions derived from it inherit it.
+	Hash func(key interface{}) uint64
+
+	root *hamtNode
+	size int
+}
+
+// Kind of change found by PersistentMap.Diff()
+type MapChangeKind int
+
+const (
+	// Key only in the new version
+	MapAdded MapChangeKind = iota
+	// Key only in the old version
+	MapRemoved
+	// Key in both versions, with different values
+	MapUpdated
+)
+
+// A difference between two versions of a PersistentMap
+// OldVal is nil for MapAdded, NewVal is nil for MapRemoved
+type MapChange struct {
+	Kind   MapChangeKind
+	Key    interface{}
+	OldVal interface{}
+	NewVal interface{}
+}
+
+const (
+	hamtBits = 5
+	hamtMask = 1<<hamtBits - 1
+)
+
+type hamtNode struct {
+	bitmap uint32
+	// one per bit set in bitmap (in bit order), either *hamtNode or *hamtEntry
+	children []interface{}
+	// entries sharing the same full hash (only used once all the hash bits are consumed)
+	collisions []*hamtEntry
+}
+
+type hamtEntry struct {
+	hash uint64
+	key  interface{}
+	val  interface{}
+}
+
+var emptyHamtNode = &hamtNode{}
+
+// Initialize a new empty persistent map
+func NewPersistentMap() *PersistentMap {
+	m := &PersistentMap{root: emptyHamtNode}
+	m.Equals = func(a, b interface{}) bool { return reflect.DeepEqual(a, b) }
+	m.Hash = DefaultHash
+	return m
+}
+
+// Does the map contain the given key
+func (m *PersistentMap) ContainsKey(key interface{}) bool {
+	return m.get(key) != nil
+}
+
+// Returns the changes needed to go from this map to other
+// Sub tries shared by both versions are skipped, so diffing two versions of
+// the same map is proportional to the number of changes rather than to the size.
+// Returns a Slice of MapChange
+func (m *PersistentMap) Diff(other *PersistentMap) *Slice {
+	changes := NewSlice()
+	m.diff(m.root, other.root, 0, func(change MapChange) bool {
+		changes.slice = append(changes.slice, change)
+		return false
+	})
+	return changes
+}
+
+// Apply the function to all the entries
+// If the function returns true (stop), iteration will stop
+func (m *PersistentMap) Each(f func(key, val interface{}) (stop bool)) {
+	m.root.each(func(e *hamtEntry) bool {
+		return f(e.key, e.val)
+	})
+}
+
+// Do both maps hold the same entries (keys and values compared with Equals)
+func (m *PersistentMap) EqualTo(other *PersistentMap) bool {
+	if m.size != other.size {
+		return false
+	}
+	equal := true
+	m.diff(m.root, other.root, 0, func(change MapChange) bool {
+		equal = false
+		return true
+	})
+	return equal
+}
+
+// Apply a function to find an entry in the map (iteratively)
+// Returns the key of the first entry for which the function returns true
+// found is false if there was no match.
+func (m *PersistentMap) Find(f func(key, val interface{}) bool) (key interface{}, found bool) {
+	m.Each(func(k, v interface{}) bool {
+		if f(k, v) {
+			key, found = k, true
+		}
+		return found
+	})
+	return key, found
+}
+
+// Apply a function to find all the entries for which the function returns true
+// Returns a new PersistentMap made of the matches.
+func (m *PersistentMap) FindAll(f func(key, val interface{}) bool) *PersistentMap {
+	results := m.empty()
+	m.root.each(func(e *hamtEntry) bool {
+		if f(e.key, e.val) {
+			results.root, _ = results.set(results.root, 0, e)
+			results.size++
+		}
+		return false
+	})
+	return results
+}
+
+// Set value of ptr to the value associated with key
+// Return false (and leave ptr untouched) if the key is not in the map
+func (m *PersistentMap) Get(key interface{}, ptr interface{}) (found bool) {
+	e := m.get(key)
+	if e == nil {
+		return false
+	}
+	PtrToVal(ptr).Set(reflect.ValueOf(e.val))
+	return true
+}
+
+// Is this map empty
+func (m *PersistentMap) IsEmpty() bool {
+	return m.size == 0
+}
+
+// Returns a new Slice of all the keys
+func (m *PersistentMap) Keys() *Slice {
+	keys := NewSlice()
+	m.root.each(func(e *hamtEntry) bool {
+		keys.slice = append(keys.slice, e.key)
+		return false
+	})
+	return keys
+}
+
+// Number of entries in the map
+func (m *PersistentMap) Len() int {
+	return m.size
+}
+
+// Reduce is used to iterate through every entry in the map to reduce the map
+// into a single value called the reduction.
+// Works like Slice.Reduce() but is given each key and value.
+func (m *PersistentMap) Reduce(startVal interface{}, f func(reduction interface{}, key, val interface{}) interface{}) interface{} {
+	reduction := startVal
+	m.root.each(func(e *hamtEntry) bool {
+		reduction = f(reduction, e.key, e.val)
+		return false
+	})
+	return reduction
+}
+
+// Returns a new version of the map without the given key
+// Returns this map itself if the key is not present.
+func (m *PersistentMap) Remove(key interface{}) *PersistentMap {
+	result, removed := m.remove(m.root, 0, m.Hash(key), key)
+	if !removed {
+		return m
+	}
+	root := emptyHamtNode
+	if result != nil {
+		root = result.(*hamtNode)
+	}
+	return &PersistentMap{Equals: m.Equals, Hash: m.Hash, root: root, size: m.size - 1}
+}
+
+// Returns a new version of the map with the value associated to key (added or replaced)
+func (m *PersistentMap) Set(key, val interface{}) *PersistentMap {
+	root, added := m.set(m.root, 0, &hamtEntry{hash: m.Hash(key), key: key, val: val})
+	size := m.size
+	if added {
+		size++
+	}
+	return &PersistentMap{Equals: m.Equals, Hash: m.Hash, root: root, size: size}
+}
+
+// impl String interface
+func (m *PersistentMap) String() string {
+	var buf bytes.Buffer
+	m.Each(func(key, val interface{}) bool {
+		if buf.Len() != 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString(fmt.Sprintf("%v:%v", key, val))
+		return false
+	})
+	return fmt.Sprintf("PersistentMap[%d] [%s]", m.size, buf.String())
+}
+
+// Returns a new Slice of all the values (in the same order as Keys())
+func (m *PersistentMap) Vals() *Slice {
+	vals := NewSlice()
+	m.root.each(func(e *hamtEntry) bool {
+		vals.slice = append(vals.slice, e.val)
+		return false
+	})
+	return vals
+}
+
+// Report the differences between the a and b sub tries (at the given shift)
+// Returns true if f asked to stop
+func (m *PersistentMap) diff(a, b *hamtNode, shift uint, f func(MapChange) bool) (stop bool) {
+	if a == b {
+		return false
+	}
+	if shift >= 64 {
+		return m.diffEntries(a.collisions, b.collisions, f)
+	}
+	for bitmap := a.bitmap | b.bitmap; bitmap != 0; bitmap &= bitmap - 1 {
+		bit := bitmap & -bitmap
+		var childA, childB interface{}
+		if a.bitmap&bit != 0 {
+			childA = a.children[bits.OnesCount32(a.bitmap&(bit-1))]
+		}
+		if b.bitmap&bit != 0 {
+			childB = b.children[bits.OnesCount32(b.bitmap&(bit-1))]
+		}
+		nodeA, aIsNode := childA.(*hamtNode)
+		nodeB, bIsNode := childB.(*hamtNode)
+		if aIsNode && bIsNode {
+			if m.diff(nodeA, nodeB, shift+hamtBits, f) {
+				return true
+			}
+			continue
+		}
+		if childA == childB {
+			continue
+		}
+		if m.diffEntries(hamtEntries(childA), hamtEntries(childB), f) {
+			return true
+		}
+	}
+	return false
+}
+
+// Report the differences between two (small) lists of entries
+func (m *PersistentMap) diffEntries(a, b []*hamtEntry, f func(MapChange) bool) (stop bool) {
+	for _, ea := range a {
+		var match *hamtEntry
+		for _, eb := range b {
+			if ea.hash == eb.hash && m.Equals(ea.key, eb.key) {
+				match = eb
+				break
+			}
+		}
+		if match == nil {
+			if f(MapChange{Kind: MapRemoved, Key: ea.key, OldVal: ea.val}) {
+				return true
+			}
+		} else if match != ea && !m.Equals(ea.val, match.val) {
+			if f(MapChange{Kind: MapUpdated, Key: ea.key, OldVal: ea.val, NewVal: match.val}) {
+				return true
+			}
+		}
+	}
+	for _, eb := range b {
+		found := false
+		for _, ea := range a {
+			if ea.hash == eb.hash && m.Equals(ea.key, eb.key) {
+				found = true
+				break
+			}
+		}
+		if !found && f(MapChange{Kind: MapAdded, Key: eb.key, NewVal: eb.val}) {
+			return true
+		}
+	}
+	return false
+}
+
+// New empty map using the same Equals and Hash functions
+func (m *PersistentMap) empty() *PersistentMap {
+	return &PersistentMap{Equals: m.Equals, Hash: m.Hash, root: emptyHamtNode}
+}
+
+// Find the entry for key, nil if none
+func (m *PersistentMap) get(key interface{}) *hamtEntry {
+	hash := m.Hash(key)
+	node := m.root
+	for shift := uint(0); ; shift += hamtBits {
+		if shift >= 64 {
+			for _, e := range node.collisions {
+				if m.Equals(e.key, key) {
+					return e
+				}
+			}
+			return nil
+		}
+		bit := uint32(1) << ((hash >> shift) & hamtMask)
+		if node.bitmap&bit == 0 {
+			return nil
+		}
+		switch child := node.children[bits.OnesCount32(node.bitmap&(bit-1))].(type) {
+		case *hamtNode:
+			node = child
+		case *hamtEntry:
+			if child.hash == hash && m.Equals(child.key, key) {
+				return child
+			}
+			return nil
+		}
+	}
+}
+
+// Make a node holding both entries (with different keys) at the given shift
+func (m *PersistentMap) merge(shift uint, e1, e2 *hamtEntry) *hamtNode {
+	if shift >= 64 {
+		return &hamtNode{collisions: []*hamtEntry{e1, e2}}
+	}
+	b1 := uint32(1) << ((e1.hash >> shift) & hamtMask)
+	b2 := uint32(1) << ((e2.hash >> shift) & hamtMask)
+	if b1 == b2 {
+		return &hamtNode{bitmap: b1, children: []interface{}{m.merge(shift+hamtBits, e1, e2)}}
+	}
+	if b1 > b2 {
+		e1, e2 = e2, e1
+	}
+	return &hamtNode{bitmap: b1 | b2, children: []interface{}{e1, e2}}
+}
+
+// Copy of node without key, nil if it would be empty
+// A node left with a single entry (and no sub node) is replaced by that entry
+// in its parent, so equal contents always have the same shape.
+func (m *PersistentMap) remove(node *hamtNode, shift uint, hash uint64, key interface{}) (interface{}, bool) {
+	if shift >= 64 {
+		for i, e := range node.collisions {
+			if m.Equals(e.key, key) {
+				if len(node.collisions) == 2 {
+					return node.collisions[1-i], true
+				}
+				collisions := make([]*hamtEntry, 0, len(node.collisions)-1)
+				collisions = append(collisions, node.collisions[:i]...)
+				collisions = append(collisions, node.collisions[i+1:]...)
+				return &hamtNode{collisions: collisions}, true
+			}
+		}
+		return node, false
+	}
+	bit := uint32(1) << ((hash >> shift) & hamtMask)
+	if node.bitmap&bit == 0 {
+		return node, false
+	}
+	idx := bits.OnesCount32(node.bitmap & (bit - 1))
+	var replacement interface{}
+	switch child := node.children[idx].(type) {
+	case *hamtNode:
+		var removed bool
+		if replacement, removed = m.remove(child, shift+hamtBits, hash, key); !removed {
+			return node, false
+		}
+	case *hamtEntry:
+		if child.hash != hash || !m.Equals(child.key, key) {
+			return node, false
+		}
+	}
+	if replacement != nil {
+		children := make([]interface{}, len(node.children))
+		copy(children, node.children)
+		children[idx] = replacement
+		return m.compact(&hamtNode{bitmap: node.bitmap, children: children}, shift), true
+	}
+	if len(node.children) == 1 {
+		return nil, true
+	}
+	children := make([]interface{}, 0, len(node.children)-1)
+	children = append(children, node.children[:idx]...)
+	children = append(children, node.children[idx+1:]...)
+	return m.compact(&hamtNode{bitmap: node.bitmap &^ bit, children: children}, shift), true
+}
+
+// Returns the single entry of a (non root) node, or the node itself
+func (m *PersistentMap) compact(node *hamtNode, shift uint) interface{} {
+	if shift == 0 || len(node.children) != 1 {
+		return node
+	}
+	if e, ok := node.children[0].(*hamtEntry); ok {
+		return e
+	}
+	return node
+}
+
+// Copy of node with the entry set, added is false if the key was already present
+func (m *PersistentMap) set(node *hamtNode, shift uint, entry *hamtEntry) (*hamtNode, bool) {
+	if shift >= 64 {
+		collisions := make([]*hamtEntry, len(node.collisions), len(node.collisions)+1)
+		copy(collisions, node.collisions)
+		for i, e := range collisions {
+			if m.Equals(e.key, entry.key) {
+				collisions[i] = entry
+				return &hamtNode{collisions: collisions}, false
+			}
+		}
+		return &hamtNode{collisions: append(collisions, entry)}, true
+	}
+	bit := uint32(1) << ((entry.hash >> shift) & hamtMask)
+	idx := bits.OnesCount32(node.bitmap & (bit - 1))
+	if node.bitmap&bit == 0 {
+		children := make([]interface{}, 0, len(node.children)+1)
+		children = append(children, node.children[:idx]...)
+		children = append(children, entry)
+		children = append(children, node.children[idx:]...)
+		return &hamtNode{bitmap: node.bitmap | bit, children: children}, true
+	}
+	children := make([]interface{}, len(node.children))
+	copy(children, node.children)
+	added := false
+	switch child := children[idx].(type) {
+	case *hamtNode:
+		children[idx], added = m.set(child, shift+hamtBits, entry)
+	case *hamtEntry:
+		if child.hash == entry.hash && m.Equals(child.key, entry.key) {
+			children[idx] = entry
+		} else {
+			children[idx], added = m.merge(shift+hamtBits, child, entry), true
+		}
+	}
+	return &hamtNode{bitmap: node.bitmap, children: children}, added
+}
+
+// Call f on all the entries of the node (and sub nodes)
+// Returns true if f asked to stop
+func (n *hamtNode) each(f func(*hamtEntry) bool) (stop bool) {
+	for _, e := range n.collisions {
+		if f(e) {
+			return true
+		}
+	}
+	for _, child := range n.children {
+		switch c := child.(type) {
+		case *hamtNode:
+			if c.each(f) {
+				return true
+			}
+		case *hamtEntry:
+			if f(c) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// All the entries of a child (*hamtNode or *hamtEntry, or nil)
+func hamtEntries(child interface{}) []*hamtEntry {
+	switch c := child.(type) {
+	case *hamtNode:
+		var entries []*hamtEntry
+		c.each(func(e *hamtEntry) bool {
+			entries = append(entries, e)
+			return false
+		})
+		return entries
+	case *hamtEntry:
+		return []*hamtEntry{c}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"fmt"
+	"github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestPersistentMap(t *testing.T) {
+	var result int
+
+	convey.Convey("Set & Get", t, func() {
+		m := NewPersistentMap()
+		m1 := m.Set("a", 1).Set("b", 2)
+		m2 := m1.Set("a", 11).Set("c", 3)
+		convey.So(m.IsEmpty(), convey.ShouldBeTrue)
+		convey.So(m1.Len(), convey.ShouldEqual, 2)
+		convey.So(m2.Len(), convey.ShouldEqual, 3)
+		convey.So(m1.Get("a", &result), convey.ShouldBeTrue)
+		convey.So(result, convey.ShouldEqual, 1)
+		m2.Get("a", &result)
+		convey.So(result, convey.ShouldEqual, 11)
+		convey.So(m1.Get("c", &result), convey.ShouldBeFalse)
+		convey.So(m2.ContainsKey("c"), convey.ShouldBeTrue)
+	})
+
+	convey.Convey("Many entries", t, func() {
+		m := testPersistentMap(5000)
+		convey.So(m.Len(), convey.ShouldEqual, 5000)
+		for i := 0; i < 5000; i += 7 {
+			m.Get(i, &result)
+			if result != i*10 {
+				convey.So(result, convey.ShouldEqual, i*10)
+			}
+		}
+		for i := 0; i < 5000; i += 2 {
+			m = m.Remove(i)
+		}
+		convey.So(m.Len(), convey.ShouldEqual, 2500)
+		convey.So(m.ContainsKey(2), convey.ShouldBeFalse)
+		convey.So(m.ContainsKey(3), convey.ShouldBeTrue)
+		convey.So(m.Remove(2), convey.ShouldEqual, m) // not present
+		for i := 1; i < 5000; i += 2 {
+			m = m.Remove(i)
+		}
+		convey.So(m.IsEmpty(), convey.ShouldBeTrue)
+		convey.So(m.root, convey.ShouldEqual, emptyHamtNode)
+	})
+
+	convey.Convey("Collisions", t, func() {
+		m := NewPersistentMap()
+		m.Hash = func(key interface{}) uint64 { return uint64(key.(int) % 3) }
+		for i := 0; i != 30; i++ {
+			m = m.Set(i, i)
+		}
+		convey.So(m.Len(), convey.ShouldEqual, 30)
+		m.Get(25, &result)
+		convey.So(result, convey.ShouldEqual, 25)
+		m2 := m.Set(25, -25).Remove(4)
+		convey.So(m2.Len(), convey.ShouldEqual, 29)
+		m2.Get(25, &result)
+		convey.So(result, convey.ShouldEqual, -25)
+		convey.So(m2.Diff(m).Len(), convey.ShouldEqual, 2)
+		for i := 0; i != 30; i++ {
+			m = m.Remove(i)
+		}
+		convey.So(m.IsEmpty(), convey.ShouldBeTrue)
+	})
+
+	convey.Convey("Functional methods", t, func() {
+		m := testPersistentMap(10)
+		convey.So(m.Keys().Len(), convey.ShouldEqual, 10)
+		sum := m.Reduce(0, func(reduction interface{}, key, val interface{}) interface{} {
+			return reduction.(int) + val.(int)
+		})
+		convey.So(sum, convey.ShouldEqual, 450)
+		key, found := m.Find(func(key, val interface{}) bool { return val == 70 })
+		convey.So(found, convey.ShouldBeTrue)
+		convey.So(key, convey.ShouldEqual, 7)
+		_, found = m.Find(func(key, val interface{}) bool { return val == 75 })
+		convey.So(found, convey.ShouldBeFalse)
+		big := m.FindAll(func(key, val interface{}) bool { return key.(int) >= 5 })
+		convey.So(big.Len(), convey.ShouldEqual, 5)
+		convey.So(big.ContainsKey(4), convey.ShouldBeFalse)
+		n := 0
+		m.Each(func(key, val interface{}) bool {
+			n++
+			return n == 3
+		})
+		convey.So(n, convey.ShouldEqual, 3)
+		convey.So(NewPersistentMap().Set("x", 1).String(), convey.ShouldEqual, "PersistentMap[1] [x:1]")
+	})
+
+	convey.Convey("Equality & Diff", t, func() {
+		m := testPersistentMap(1000)
+		// Same content built in a different order
+		other := NewPersistentMap()
+		for i := 999; i >= 0; i-- {
+			other = other.Set(i, i*10)
+		}
+		convey.So(m.EqualTo(other), convey.ShouldBeTrue)
+		m2 := m.Set(5, -5).Remove(6).Set(1000, 1).Set(7, 70)
+		convey.So(m.EqualTo(m2), convey.ShouldBeFalse)
+		changes := m.Diff(m2)
+		convey.So(changes.Len(), convey.ShouldEqual, 3)
+		found := map[string]bool{}
+		changes.Each(func(i int, e interface{}) bool {
+			found[fmt.Sprintf("%v", e)] = true
+			return false
+		})
+		convey.So(found["{2 5 50 -5}"], convey.ShouldBeTrue)      // updated
+		convey.So(found["{1 6 60 <nil>}"], convey.ShouldBeTrue)   // removed
+		convey.So(found["{0 1000 <nil> 1}"], convey.ShouldBeTrue) // added
+		convey.So(m.Diff(m).IsEmpty(), convey.ShouldBeTrue)
+		convey.So(m2.Diff(m).Len(), convey.ShouldEqual, 3)
+		convey.So(m.Diff(NewPersistentMap()).Len(), convey.ShouldEqual, 1000)
+	})
+}
+
+// #################### TESTS DATA ############################################
+
+func testPersistentMap(size int) *PersistentMap {
+	m := NewPersistentMap()
+	for i := 0; i != size; i++ {
+		m = m.Set(i, i*10)
+	}
+	return m
+}
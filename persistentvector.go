@@ -0,0 +1,502 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Immutable (persistent) vector of "generic" elements
+// Every "modification" (Append, Set, Insert, Pop) returns a new version of the
+// vector sharing most of its structure with the previous one, which is left
+// untouched, so versions can be freely passed between goroutines.
+// It's a 32-way trie (plus a tail for fast appends), so Get and Set are
+// O(log32 n) (effectively constant) and Append is amortized O(1).
+// Note: Insert is O(n-idx) as, unlike with a RRB tree, the elements after idx
+// have to be re-appended.
+// For many successive changes use a TransientVector (See Transient()).
+type PersistentVector struct {
+	cnt   int
+	shift uint
+	root  *pvNode
+	// last (up to 32) elements, not yet pushed in the trie
+	tail []interface{}
+}
+
+// Mutable, single goroutine, builder of PersistentVector
+// It mutates the nodes it owns in place, rather than copying them, which
+// makes batches of changes much cheaper, once done call Persistent().
+type TransientVector struct {
+	cnt   int
+	shift uint
+	root  *pvNode
+	tail  []interface{}
+	edit  *pvEdit
+}
+
+const (
+	pvBits  = 5
+	pvWidth = 1 << pvBits
+	pvMask  = pvWidth - 1
+)
+
+// Trie node, holds either child nodes (*pvNode) or the elements (leaves)
+type pvNode struct {
+	// The transient allowed to mutate this node in place (nil if none)
+	edit  *pvEdit
+	array [pvWidth]interface{}
+}
+
+// Ownership token of a transient, nodes are only mutable while active
+type pvEdit struct {
+	active bool
+}
+
+var emptyPvNode = &pvNode{}
+
+// Initialize a new empty persistent vector
+func NewPersistentVector() *PersistentVector {
+	return &PersistentVector{shift: pvBits, root: emptyPvNode}
+}
+
+// Initialize a new persistent vector with the elements of a Slice
+func NewPersistentVectorFromSlice(s *Slice) *PersistentVector {
+	return NewPersistentVector().AppendAll(s.slice...)
+}
+
+// Returns a new vector with elem appended
+func (v *PersistentVector) Append(elem interface{}) *PersistentVector {
+	if v.cnt-v.tailOff() < pvWidth {
+		tail := make([]interface{}, len(v.tail)+1)
+		copy(tail, v.tail)
+		tail[len(v.tail)] = elem
+		return &PersistentVector{cnt: v.cnt + 1, shift: v.shift, root: v.root, tail: tail}
+	}
+	// tail is full, push it into the trie
+	tailNode := &pvNode{}
+	copy(tailNode.array[:], v.tail)
+	root, shift := v.root, v.shift
+	if v.cnt>>pvBits > 1<<shift {
+		// root overflow
+		root = &pvNode{}
+		root.array[0] = v.root
+		root.array[1] = newPvPath(nil, v.shift, tailNode)
+		shift += pvBits
+	} else {
+		root = v.pushTail(v.shift, v.root, tailNode)
+	}
+	return &PersistentVector{cnt: v.cnt + 1, shift: shift, root: root, tail: []interface{}{elem}}
+}
+
+// Returns a new vector with all the elems appended
+func (v *PersistentVector) AppendAll(elems ...interface{}) *PersistentVector {
+	t := v.Transient()
+	for _, elem := range elems {
+		t.Append(elem)
+	}
+	return t.Persistent()
+}
+
+// Does the vector contain the given element (using reflect.DeepEqual)
+func (v *PersistentVector) Contains(elem interface{}) bool {
+	found := false
+	v.Each(func(i int, e interface{}) bool {
+		found = reflect.DeepEqual(e, elem)
+		return found
+	})
+	return found
+}
+
+// Apply the function to the whole vector (in order)
+// If the function returns true (stop), iteration will stop
+func (v *PersistentVector) Each(f func(int, interface{}) (stop bool)) {
+	for i := 0; i < v.cnt; i += pvWidth {
+		for j, e := range v.arrayFor(i) {
+			if i+j >= v.cnt || f(i+j, e) {
+				return
+			}
+		}
+	}
+}
+
+// Set value of ptr to vector[idx]
+// If idx is negative then idx element from the end (-1 = last)
+// Will panic if the index is out of bounds
+func (v *PersistentVector) Get(idx int, ptr interface{}) {
+	var err error
+	if idx, err = handlePvIndex(idx, v.cnt); err != nil {
+		panic(err.Error())
+	}
+	PtrToVal(ptr).Set(reflect.ValueOf(v.arrayFor(idx)[idx&pvMask]))
+}
+
+// Returns a new vector with elem inserted before index idx
+// Can use negative index, idx = Len() appends the element
+func (v *PersistentVector) Insert(idx int, elem interface{}) *PersistentVector {
+	if idx != v.cnt { // inserting at cnt appends
+		var err error
+		if idx, err = handlePvIndex(idx, v.cnt); err != nil {
+			panic(err.Error())
+		}
+	}
+	rest := make([]interface{}, 0, v.cnt-idx)
+	v.Each(func(i int, e interface{}) bool {
+		if i >= idx {
+			rest = append(rest, e)
+		}
+		return false
+	})
+	t := v.Transient()
+	for t.cnt > idx {
+		t.Pop()
+	}
+	t.Append(elem)
+	for _, e := range rest {
+		t.Append(e)
+	}
+	return t.Persistent()
+}
+
+// Is this vector empty
+func (v *PersistentVector) IsEmpty() bool {
+	return v.cnt == 0
+}
+
+// Length of this vector
+func (v *PersistentVector) Len() int {
+	return v.cnt
+}
+
+// Returns a new vector without the last element
+// Will panic if the vector is empty
+func (v *PersistentVector) Pop() *PersistentVector {
+	if v.cnt == 0 {
+		panic("Can't Pop empty PersistentVector !")
+	}
+	if v.cnt == 1 {
+		return NewPersistentVector()
+	}
+	if v.cnt-v.tailOff() > 1 {
+		return &PersistentVector{cnt: v.cnt - 1, shift: v.shift, root: v.root, tail: v.tail[:len(v.tail)-1]}
+	}
+	// tail would be empty, the last leaf of the trie becomes the tail
+	tail := v.arrayFor(v.cnt - 2)
+	root, shift := v.popTail(v.shift, v.root), v.shift
+	if root == nil {
+		root = emptyPvNode
+	}
+	if shift > pvBits && root.array[1] == nil {
+		root = root.array[0].(*pvNode)
+		shift -= pvBits
+	}
+	return &PersistentVector{cnt: v.cnt - 1, shift: shift, root: root, tail: tail}
+}
+
+// Returns a new vector with the element at index idx replaced by elem
+// Can use negative index
+func (v *PersistentVector) Set(idx int, elem interface{}) *PersistentVector {
+	var err error
+	if idx, err = handlePvIndex(idx, v.cnt); err != nil {
+		panic(err.Error())
+	}
+	if idx >= v.tailOff() {
+		tail := make([]interface{}, len(v.tail))
+		copy(tail, v.tail)
+		tail[idx&pvMask] = elem
+		return &PersistentVector{cnt: v.cnt, shift: v.shift, root: v.root, tail: tail}
+	}
+	return &PersistentVector{cnt: v.cnt, shift: v.shift, root: v.doSet(v.shift, v.root, idx, elem), tail: v.tail}
+}
+
+// impl String interface
+func (v *PersistentVector) String() string {
+	return fmt.Sprintf("PersistentVector[%d] %v", v.cnt, v.ToSlice().slice)
+}
+
+// Copy the elements into a new (mutable) Slice
+func (v *PersistentVector) ToSlice() *Slice {
+	s := NewSlice()
+	s.slice = make([]interface{}, 0, v.cnt)
+	v.Each(func(i int, e interface{}) bool {
+		s.slice = append(s.slice, e)
+		return false
+	})
+	return s
+}
+
+// Returns a transient (mutable) copy of this vector, this vector is not affected
+func (v *PersistentVector) Transient() *TransientVector {
+	edit := &pvEdit{active: true}
+	root := &pvNode{edit: edit, array: v.root.array}
+	tail := make([]interface{}, len(v.tail), pvWidth)
+	copy(tail, v.tail)
+	return &TransientVector{cnt: v.cnt, shift: v.shift, root: root, tail: tail, edit: edit}
+}
+
+// Leaf array holding the element at index i
+func (v *PersistentVector) arrayFor(i int) []interface{} {
+	if i >= v.tailOff() {
+		return v.tail
+	}
+	return pvLeaf(v.root, v.shift, i)
+}
+
+// Copy of node with the element at index i replaced
+func (v *PersistentVector) doSet(level uint, node *pvNode, i int, elem interface{}) *pvNode {
+	ret := &pvNode{array: node.array}
+	if level == 0 {
+		ret.array[i&pvMask] = elem
+	} else {
+		subidx := (i >> level) & pvMask
+		ret.array[subidx] = v.doSet(level-pvBits, node.array[subidx].(*pvNode), i, elem)
+	}
+	return ret
+}
+
+// Copy of node without its last leaf (nil if it would be empty)
+func (v *PersistentVector) popTail(level uint, node *pvNode) *pvNode {
+	subidx := ((v.cnt - 2) >> level) & pvMask
+	if level > pvBits {
+		child := v.popTail(level-pvBits, node.array[subidx].(*pvNode))
+		if child == nil && subidx == 0 {
+			return nil
+		}
+		ret := &pvNode{array: node.array}
+		ret.array[subidx] = nil
+		if child != nil {
+			ret.array[subidx] = child
+		}
+		return ret
+	}
+	if subidx == 0 {
+		return nil
+	}
+	ret := &pvNode{array: node.array}
+	ret.array[subidx] = nil
+	return ret
+}
+
+// Copy of parent with tailNode added as its last leaf
+func (v *PersistentVector) pushTail(level uint, parent *pvNode, tailNode *pvNode) *pvNode {
+	subidx := ((v.cnt - 1) >> level) & pvMask
+	ret := &pvNode{array: parent.array}
+	if level == pvBits {
+		ret.array[subidx] = tailNode
+	} else if child := parent.array[subidx]; child != nil {
+		ret.array[subidx] = v.pushTail(level-pvBits, child.(*pvNode), tailNode)
+	} else {
+		ret.array[subidx] = newPvPath(nil, level-pvBits, tailNode)
+	}
+	return ret
+}
+
+// Index of the first element in the tail
+func (v *PersistentVector) tailOff() int {
+	return pvTailOff(v.cnt)
+}
+
+// Append elem (in place)
+// Return the transient pointer to allow method chaining.
+func (t *TransientVector) Append(elem interface{}) *TransientVector {
+	t.ensureActive()
+	if t.cnt-pvTailOff(t.cnt) < pvWidth {
+		t.tail = append(t.tail, elem)
+		t.cnt++
+		return t
+	}
+	tailNode := &pvNode{edit: t.edit}
+	copy(tailNode.array[:], t.tail)
+	t.tail = make([]interface{}, 1, pvWidth)
+	t.tail[0] = elem
+	if t.cnt>>pvBits > 1<<t.shift {
+		root := &pvNode{edit: t.edit}
+		root.array[0] = t.root
+		root.array[1] = newPvPath(t.edit, t.shift, tailNode)
+		t.root = root
+		t.shift += pvBits
+	} else {
+		t.root = t.pushTail(t.shift, t.root, tailNode)
+	}
+	t.cnt++
+	return t
+}
+
+// Set value of ptr to transient[idx]
+// If idx is negative then idx element from the end (-1 = last)
+// Will panic if the index is out of bounds
+func (t *TransientVector) Get(idx int, ptr interface{}) {
+	t.ensureActive()
+	var err error
+	if idx, err = handlePvIndex(idx, t.cnt); err != nil {
+		panic(err.Error())
+	}
+	var elem interface{}
+	if idx >= pvTailOff(t.cnt) {
+		elem = t.tail[idx&pvMask]
+	} else {
+		elem = pvLeaf(t.root, t.shift, idx)[idx&pvMask]
+	}
+	PtrToVal(ptr).Set(reflect.ValueOf(elem))
+}
+
+// Length of this transient
+func (t *TransientVector) Len() int {
+	return t.cnt
+}
+
+// Make this transient a persistent vector (cheap, no copy)
+// The transient can't be used anymore after that.
+func (t *TransientVector) Persistent() *PersistentVector {
+	t.ensureActive()
+	t.edit.active = false
+	if t.cnt == 0 {
+		return NewPersistentVector()
+	}
+	return &PersistentVector{cnt: t.cnt, shift: t.shift, root: t.root, tail: t.tail}
+}
+
+// Remove the last element (in place)
+// Will panic if the transient is empty
+// Return the transient pointer to allow method chaining.
+func (t *TransientVector) Pop() *TransientVector {
+	t.ensureActive()
+	if t.cnt == 0 {
+		panic("Can't Pop empty TransientVector !")
+	}
+	if t.cnt == 1 || t.cnt-pvTailOff(t.cnt) > 1 {
+		t.tail[len(t.tail)-1] = nil
+		t.tail = t.tail[:len(t.tail)-1]
+		t.cnt--
+		return t
+	}
+	leaf := pvLeaf(t.root, t.shift, t.cnt-2)
+	t.tail = make([]interface{}, pvWidth, pvWidth)
+	copy(t.tail, leaf)
+	root := t.popTail(t.shift, t.root)
+	if root == nil {
+		root = &pvNode{edit: t.edit}
+	}
+	if t.shift > pvBits && root.array[1] == nil {
+		root = t.ensureEditable(root.array[0].(*pvNode))
+		t.shift -= pvBits
+	}
+	t.root = root
+	t.cnt--
+	return t
+}
+
+// Replace the element at index idx (in place)
+// Can use negative index
+// Return the transient pointer to allow method chaining.
+func (t *TransientVector) Set(idx int, elem interface{}) *TransientVector {
+	t.ensureActive()
+	var err error
+	if idx, err = handlePvIndex(idx, t.cnt); err != nil {
+		panic(err.Error())
+	}
+	if idx >= pvTailOff(t.cnt) {
+		t.tail[idx&pvMask] = elem
+		return t
+	}
+	node := t.root
+	for level := t.shift; level > 0; level -= pvBits {
+		subidx := (idx >> level) & pvMask
+		child := t.ensureEditable(node.array[subidx].(*pvNode))
+		node.array[subidx] = child
+		node = child
+	}
+	node.array[idx&pvMask] = elem
+	return t
+}
+
+// Panic if the transient was made persistent already
+func (t *TransientVector) ensureActive() {
+	if !t.edit.active {
+		panic("TransientVector used after Persistent() !")
+	}
+}
+
+// Return node itself if owned by this transient, otherwise an owned copy
+func (t *TransientVector) ensureEditable(node *pvNode) *pvNode {
+	if node.edit == t.edit {
+		return node
+	}
+	return &pvNode{edit: t.edit, array: node.array}
+}
+
+// Remove the last leaf of node (in place), returns nil if it becomes empty
+func (t *TransientVector) popTail(level uint, node *pvNode) *pvNode {
+	node = t.ensureEditable(node)
+	subidx := ((t.cnt - 2) >> level) & pvMask
+	if level > pvBits {
+		child := t.popTail(level-pvBits, node.array[subidx].(*pvNode))
+		if child == nil && subidx == 0 {
+			return nil
+		}
+		node.array[subidx] = nil
+		if child != nil {
+			node.array[subidx] = child
+		}
+		return node
+	}
+	if subidx == 0 {
+		return nil
+	}
+	node.array[subidx] = nil
+	return node
+}
+
+// Add tailNode as the last leaf of parent (in place)
+func (t *TransientVector) pushTail(level uint, parent *pvNode, tailNode *pvNode) *pvNode {
+	parent = t.ensureEditable(parent)
+	subidx := ((t.cnt - 1) >> level) & pvMask
+	if level == pvBits {
+		parent.array[subidx] = tailNode
+	} else if child := parent.array[subidx]; child != nil {
+		parent.array[subidx] = t.pushTail(level-pvBits, child.(*pvNode), tailNode)
+	} else {
+		parent.array[subidx] = newPvPath(t.edit, level-pvBits, tailNode)
+	}
+	return parent
+}
+
+// Validate the index is in the vector bounds
+// Also turn negative indexes into index from the end of the vector (-1 = last)
+func handlePvIndex(idx, cnt int) (int, error) {
+	if idx < 0 {
+		idx = cnt + idx
+	}
+	if idx >= cnt || idx < 0 {
+		return idx, errors.New(fmt.Sprintf("Invalid vector index: %d", idx))
+	}
+	return idx, nil
+}
+
+// Chain of nodes from level down to node
+func newPvPath(edit *pvEdit, level uint, node *pvNode) *pvNode {
+	if level == 0 {
+		return node
+	}
+	ret := &pvNode{edit: edit}
+	ret.array[0] = newPvPath(edit, level-pvBits, node)
+	return ret
+}
+
+// Leaf array (from the trie) holding the element at index i
+func pvLeaf(root *pvNode, shift uint, i int) []interface{} {
+	node := root
+	for level := shift; level > 0; level -= pvBits {
+		node = node.array[(i>>level)&pvMask].(*pvNode)
+	}
+	return node.array[:]
+}
+
+// Index of the first element in the tail of a vector of size cnt
+func pvTailOff(cnt int) int {
+	if cnt < pvWidth {
+		return 0
+	}
+	return ((cnt - 1) >> pvBits) << pvBits
+}
@@ -0,0 +1,142 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestPersistentVector(t *testing.T) {
+	var result int
+
+	convey.Convey("Append & Get", t, func() {
+		v := NewPersistentVector()
+		versions := []*PersistentVector{v}
+		for i := 0; i != 2000; i++ {
+			v = v.Append(i)
+			versions = append(versions, v)
+		}
+		convey.So(v.Len(), convey.ShouldEqual, 2000)
+		for _, i := range []int{0, 31, 32, 1023, 1024, 1055, 1056, 1999} {
+			v.Get(i, &result)
+			convey.So(result, convey.ShouldEqual, i)
+		}
+		v.Get(-1, &result)
+		convey.So(result, convey.ShouldEqual, 1999)
+		convey.So(func() { v.Get(2000, &result) }, convey.ShouldPanic)
+		// older versions are untouched
+		convey.So(versions[1500].Len(), convey.ShouldEqual, 1500)
+		versions[1500].Get(-1, &result)
+		convey.So(result, convey.ShouldEqual, 1499)
+		convey.So(versions[0].IsEmpty(), convey.ShouldBeTrue)
+	})
+
+	convey.Convey("Set", t, func() {
+		v := testPersistentVector(1100)
+		v2 := v.Set(5, -5).Set(1090, -1090).Set(-1, -1099)
+		v2.Get(5, &result)
+		convey.So(result, convey.ShouldEqual, -5)
+		v2.Get(1090, &result)
+		convey.So(result, convey.ShouldEqual, -1090)
+		v2.Get(1099, &result)
+		convey.So(result, convey.ShouldEqual, -1099)
+		v.Get(5, &result)
+		convey.So(result, convey.ShouldEqual, 5)
+		v.Get(1099, &result)
+		convey.So(result, convey.ShouldEqual, 1099)
+	})
+
+	convey.Convey("Pop", t, func() {
+		v := testPersistentVector(1100)
+		full := v
+		for v.Len() > 0 {
+			n := v.Len()
+			v = v.Pop()
+			convey.So(v.Len(), convey.ShouldEqual, n-1)
+			if n > 1 {
+				v.Get(-1, &result)
+				if result != n-2 {
+					convey.So(result, convey.ShouldEqual, n-2)
+				}
+			}
+		}
+		convey.So(func() { v.Pop() }, convey.ShouldPanic)
+		convey.So(full.Len(), convey.ShouldEqual, 1100)
+		full.Get(1099, &result)
+		convey.So(result, convey.ShouldEqual, 1099)
+	})
+
+	convey.Convey("Insert", t, func() {
+		v := NewPersistentVectorFromSlice(NewSlice().AppendAll("A", "B", "C"))
+		v2 := v.Insert(0, "X").Insert(-1, "Y")
+		convey.So(v2.ToSlice().Join(""), convey.ShouldEqual, "XABYC")
+		convey.So(v.ToSlice().Join(""), convey.ShouldEqual, "ABC")
+		big := testPersistentVector(100).Insert(40, -1)
+		big.Get(40, &result)
+		convey.So(result, convey.ShouldEqual, -1)
+		big.Get(41, &result)
+		convey.So(result, convey.ShouldEqual, 40)
+		convey.So(big.Len(), convey.ShouldEqual, 101)
+		convey.So(v.Insert(3, "Z").ToSlice().Join(""), convey.ShouldEqual, "ABCZ")
+		convey.So(v.Insert(-3, "Z").ToSlice().Join(""), convey.ShouldEqual, "ZABC")
+		convey.So(func() { v.Insert(4, "Z") }, convey.ShouldPanic)
+		convey.So(func() { v.Insert(-4, "Z") }, convey.ShouldPanic)
+		convey.So(NewPersistentVector().Insert(0, "Z").Len(), convey.ShouldEqual, 1)
+	})
+
+	convey.Convey("Transient", t, func() {
+		v := testPersistentVector(40)
+		tr := v.Transient()
+		for i := 40; i != 1200; i++ {
+			tr.Append(i)
+		}
+		tr.Set(3, -3).Set(1150, -1150).Pop()
+		convey.So(tr.Len(), convey.ShouldEqual, 1199)
+		tr.Get(3, &result)
+		convey.So(result, convey.ShouldEqual, -3)
+		v2 := tr.Persistent()
+		convey.So(func() { tr.Append(1) }, convey.ShouldPanic)
+		convey.So(v2.Len(), convey.ShouldEqual, 1199)
+		v2.Get(1150, &result)
+		convey.So(result, convey.ShouldEqual, -1150)
+		v2.Get(-1, &result)
+		convey.So(result, convey.ShouldEqual, 1198)
+		// the original vector is untouched
+		convey.So(v.Len(), convey.ShouldEqual, 40)
+		v.Get(3, &result)
+		convey.So(result, convey.ShouldEqual, 3)
+		// a second transient of v2 doesn't affect v2
+		tr2 := v2.Transient()
+		for tr2.Len() > 10 {
+			tr2.Pop()
+		}
+		tr2.Set(0, 99)
+		convey.So(tr2.Persistent().ToSlice().Join(","), convey.ShouldEqual, "99,1,2,-3,4,5,6,7,8,9")
+		convey.So(v2.Len(), convey.ShouldEqual, 1199)
+		v2.Get(0, &result)
+		convey.So(result, convey.ShouldEqual, 0)
+	})
+
+	convey.Convey("Each & ToSlice", t, func() {
+		v := testPersistentVector(70)
+		sum := 0
+		v.Each(func(i int, e interface{}) bool {
+			sum += e.(int)
+			return i == 64
+		})
+		convey.So(sum, convey.ShouldEqual, 2080)
+		convey.So(v.ToSlice().Len(), convey.ShouldEqual, 70)
+		convey.So(NewPersistentVector().Append(1).Append(2).String(), convey.ShouldEqual, "PersistentVector[2] [1 2]")
+	})
+}
+
+// #################### TESTS DATA ############################################
+
+func testPersistentVector(size int) *PersistentVector {
+	t := NewPersistentVector().Transient()
+	for i := 0; i != size; i++ {
+		t.Append(i)
+	}
+	return t.Persistent()
+}
@@ -0,0 +1,448 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"reflect"
+)
+
+// Read only view of a Slice
+// It only exposes the non mutating methods and is backed by the live slice
+// (no copy is made), so changes made to the slice are visible through the view.
+// Methods returning a new Slice (FindAll, Clone ...) return independent copies
+// which can be freely modified.
+type SliceView struct {
+	s *Slice
+}
+
+// Read only view of a Map
+// It only exposes the non mutating methods and is backed by the live map
+// (no copy is made), so changes made to the map are visible through the view.
+type MapView struct {
+	m *Map
+}
+
+// Returns a read only view of this slice (See SliceView)
+func (s *Slice) ReadOnly() *SliceView {
+	return &SliceView{s: s}
+}
+
+// Returns a read only view of this map (See MapView)
+func (m *Map) ReadOnly() *MapView {
+	return &MapView{m: m}
+}
+
+// See Slice.All()
+func (v *SliceView) All(f func(interface{}) bool) bool {
+	return v.s.All(f)
+}
+
+// See Slice.Any()
+func (v *SliceView) Any(f func(interface{}) bool) bool {
+	return v.s.Any(f)
+}
+
+// See Slice.Average()
+func (v *SliceView) Average() (float64, error) {
+	return v.s.Average()
+}
+
+// See Slice.AverageBy()
+func (v *SliceView) AverageBy(f func(interface{}) interface{}) (float64, error) {
+	return v.s.AverageBy(f)
+}
+
+// See Slice.BottomK()
+func (v *SliceView) BottomK(k int, cmp func(a, b interface{}) int) *Slice {
+	return v.s.BottomK(k, cmp)
+}
+
+// See Slice.Chunk()
+func (v *SliceView) Chunk(size int) *Slice {
+	return v.s.Chunk(size)
+}
+
+// See Slice.Clone()
+func (v *SliceView) Clone() *Slice {
+	return v.s.Clone()
+}
+
+// See Slice.CloneRange()
+func (v *SliceView) CloneRange(from, to int) *Slice {
+	return v.s.CloneRange(from, to)
+}
+
+// See Slice.Contains()
+func (v *SliceView) Contains(elem interface{}) bool {
+	return v.s.Contains(elem)
+}
+
+// See Slice.ContainsAll()
+func (v *SliceView) ContainsAll(elems ...interface{}) bool {
+	return v.s.ContainsAll(elems...)
+}
+
+// See Slice.ContainsAny()
+func (v *SliceView) ContainsAny(elems ...interface{}) bool {
+	return v.s.ContainsAny(elems...)
+}
+
+// See Slice.DeepClone()
+func (v *SliceView) DeepClone() *Slice {
+	return v.s.DeepClone()
+}
+
+// See Slice.Distinct()
+func (v *SliceView) Distinct() *Slice {
+	return v.s.Distinct()
+}
+
+// See Slice.DistinctBy()
+func (v *SliceView) DistinctBy(f func(interface{}) (key interface{})) *Slice {
+	return v.s.DistinctBy(f)
+}
+
+// See Slice.Each()
+func (v *SliceView) Each(f func(int, interface{}) (stop bool)) {
+	v.s.Each(f)
+}
+
+// See Slice.EachRange()
+func (v *SliceView) EachRange(from, to int, f func(int, interface{}) (stop bool)) {
+	v.s.EachRange(from, to, f)
+}
+
+// See Slice.Eachr()
+func (v *SliceView) Eachr(f func(int, interface{}) (stop bool)) {
+	v.s.Eachr(f)
+}
+
+// See Slice.Except()
+func (v *SliceView) Except(other *Slice) *Slice {
+	return v.s.Except(other)
+}
+
+// See Slice.Find()
+func (v *SliceView) Find(f func(int, interface{}) (found bool)) (index int) {
+	return v.s.Find(f)
+}
+
+// See Slice.FindAll()
+func (v *SliceView) FindAll(f func(int, interface{}) (found bool)) *Slice {
+	return v.s.FindAll(f)
+}
+
+// See Slice.FlatMap()
+func (v *SliceView) FlatMap(f func(interface{}) interface{}) *Slice {
+	return v.s.FlatMap(f)
+}
+
+// See Slice.First()
+func (v *SliceView) First(ptr interface{}) {
+	v.s.First(ptr)
+}
+
+// See Slice.Get()
+func (v *SliceView) Get(idx int, ptr interface{}) {
+	v.s.Get(idx, ptr)
+}
+
+// See Slice.GetVal()
+func (v *SliceView) GetVal(idx int, ptrVal reflect.Value) {
+	v.s.GetVal(idx, ptrVal)
+}
+
+// See Slice.GroupBy()
+func (v *SliceView) GroupBy(f func(interface{}) (key interface{})) *Map {
+	return v.s.GroupBy(f)
+}
+
+// See Slice.Histogram()
+func (v *SliceView) Histogram(buckets int) (*Slice, error) {
+	return v.s.Histogram(buckets)
+}
+
+// See Slice.IndexOf()
+func (v *SliceView) IndexOf(elem interface{}) int {
+	return v.s.IndexOf(elem)
+}
+
+// See Slice.Intersect()
+func (v *SliceView) Intersect(other *Slice) *Slice {
+	return v.s.Intersect(other)
+}
+
+// See Slice.IsEmpty()
+func (v *SliceView) IsEmpty() bool {
+	return v.s.IsEmpty()
+}
+
+// See Slice.IsPermutationOf()
+func (v *SliceView) IsPermutationOf(other *Slice) bool {
+	return v.s.IsPermutationOf(other)
+}
+
+// See Slice.Join()
+func (v *SliceView) Join(sep string) string {
+	return v.s.Join(sep)
+}
+
+// See Slice.Last()
+func (v *SliceView) Last(ptr interface{}) {
+	v.s.Last(ptr)
+}
+
+// See Slice.Len()
+func (v *SliceView) Len() int {
+	return v.s.Len()
+}
+
+// See Slice.Map()
+func (v *SliceView) Map(f func(interface{}) interface{}) *Slice {
+	return v.s.Map(f)
+}
+
+// See Slice.MapIndexed()
+func (v *SliceView) MapIndexed(f func(int, interface{}) interface{}) *Slice {
+	return v.s.MapIndexed(f)
+}
+
+// See Slice.Max()
+func (v *SliceView) Max(ptr interface{}) {
+	v.s.Max(ptr)
+}
+
+// See Slice.MaxBy()
+func (v *SliceView) MaxBy(f func(interface{}) (key interface{})) int {
+	return v.s.MaxBy(f)
+}
+
+// See Slice.MaxIndex()
+func (v *SliceView) MaxIndex() int {
+	return v.s.MaxIndex()
+}
+
+// See Slice.Median()
+func (v *SliceView) Median() (float64, error) {
+	return v.s.Median()
+}
+
+// See Slice.Min()
+func (v *SliceView) Min(ptr interface{}) {
+	v.s.Min(ptr)
+}
+
+// See Slice.MinBy()
+func (v *SliceView) MinBy(f func(interface{}) (key interface{})) int {
+	return v.s.MinBy(f)
+}
+
+// See Slice.MinIndex()
+func (v *SliceView) MinIndex() int {
+	return v.s.MinIndex()
+}
+
+// See Slice.MinMax()
+func (v *SliceView) MinMax() (minIdx, maxIdx int) {
+	return v.s.MinMax()
+}
+
+// See Slice.Mode()
+func (v *SliceView) Mode() (float64, error) {
+	return v.s.Mode()
+}
+
+// See Slice.Partition()
+func (v *SliceView) Partition(f func(int, interface{}) bool) (matches, others *Slice) {
+	return v.s.Partition(f)
+}
+
+// See Slice.Peek()
+func (v *SliceView) Peek(ptr interface{}) {
+	v.s.Peek(ptr)
+}
+
+// See Slice.Percentile()
+func (v *SliceView) Percentile(p float64) (float64, error) {
+	return v.s.Percentile(p)
+}
+
+// See Slice.Product()
+func (v *SliceView) Product(others ...*Slice) *Slice {
+	return v.s.Product(others...)
+}
+
+// See Slice.Random()
+func (v *SliceView) Random(ptr interface{}, rng RandSource) {
+	v.s.Random(ptr, rng)
+}
+
+// See Slice.Reduce()
+func (v *SliceView) Reduce(startVal interface{}, f func(reduction interface{}, index int, elem interface{}) interface{}) interface{} {
+	return v.s.Reduce(startVal, f)
+}
+
+// See Slice.Sample()
+func (v *SliceView) Sample(k int, rng RandSource) *Slice {
+	return v.s.Sample(k, rng)
+}
+
+// See Slice.SampleWeighted()
+func (v *SliceView) SampleWeighted(k int, f func(interface{}) (weight float64), rng RandSource) *Slice {
+	return v.s.SampleWeighted(k, f, rng)
+}
+
+// See Slice.SplitWhen()
+func (v *SliceView) SplitWhen(f func(prev, cur interface{}) bool) *Slice {
+	return v.s.SplitWhen(f)
+}
+
+// See Slice.StdDev()
+func (v *SliceView) StdDev() (float64, error) {
+	return v.s.StdDev()
+}
+
+// impl String interface
+func (v *SliceView) String() string {
+	return v.s.String()
+}
+
+// See Slice.Sum()
+func (v *SliceView) Sum() (float64, error) {
+	return v.s.Sum()
+}
+
+// See Slice.SumBy()
+func (v *SliceView) SumBy(f func(interface{}) interface{}) (float64, error) {
+	return v.s.SumBy(f)
+}
+
+// See Slice.To()
+func (v *SliceView) To(ptr interface{}) {
+	v.s.To(ptr)
+}
+
+// See Slice.ToRange()
+func (v *SliceView) ToRange(from, to int, ptr interface{}) {
+	v.s.ToRange(from, to, ptr)
+}
+
+// See Slice.TopK()
+func (v *SliceView) TopK(k int, cmp func(a, b interface{}) int) *Slice {
+	return v.s.TopK(k, cmp)
+}
+
+// See Slice.Union()
+func (v *SliceView) Union(other *Slice) *Slice {
+	return v.s.Union(other)
+}
+
+// See Slice.Unzip()
+func (v *SliceView) Unzip() (firsts, seconds *Slice) {
+	return v.s.Unzip()
+}
+
+// See Slice.Variance()
+func (v *SliceView) Variance() (float64, error) {
+	return v.s.Variance()
+}
+
+// See Slice.Windowed()
+func (v *SliceView) Windowed(size, step int) *Slice {
+	return v.s.Windowed(size, step)
+}
+
+// See Slice.Zip()
+func (v *SliceView) Zip(other *Slice) *Slice {
+	return v.s.Zip(other)
+}
+
+// See Slice.ZipLongest()
+func (v *SliceView) ZipLongest(other *Slice, fill interface{}) *Slice {
+	return v.s.ZipLongest(other, fill)
+}
+
+// See Slice.ZipWith()
+func (v *SliceView) ZipWith(other *Slice, f func(a, b interface{}) interface{}) *Slice {
+	return v.s.ZipWith(other, f)
+}
+
+// See Map.All()
+func (v *MapView) All(f func(key, val interface{}) bool) bool {
+	return v.m.All(f)
+}
+
+// See Map.Any()
+func (v *MapView) Any(f func(key, val interface{}) bool) bool {
+	return v.m.Any(f)
+}
+
+// See Map.Clone()
+func (v *MapView) Clone() *Map {
+	return v.m.Clone()
+}
+
+// See Map.ContainsKey()
+func (v *MapView) ContainsKey(key interface{}) bool {
+	return v.m.ContainsKey(key)
+}
+
+// See Map.DeepClone()
+func (v *MapView) DeepClone() *Map {
+	return v.m.DeepClone()
+}
+
+// See Map.Each()
+func (v *MapView) Each(f func(key, val interface{}) (stop bool)) {
+	v.m.Each(f)
+}
+
+// See Map.Find()
+func (v *MapView) Find(f func(key, val interface{}) bool) (key interface{}, found bool) {
+	return v.m.Find(f)
+}
+
+// See Map.FindAll()
+func (v *MapView) FindAll(f func(key, val interface{}) bool) *Map {
+	return v.m.FindAll(f)
+}
+
+// See Map.Get()
+func (v *MapView) Get(key interface{}, ptr interface{}) (found bool) {
+	return v.m.Get(key, ptr)
+}
+
+// See Map.IsEmpty()
+func (v *MapView) IsEmpty() bool {
+	return v.m.IsEmpty()
+}
+
+// See Map.Join()
+func (v *MapView) Join(sep string) string {
+	return v.m.Join(sep)
+}
+
+// See Map.Keys()
+func (v *MapView) Keys() *Slice {
+	return v.m.Keys()
+}
+
+// See Map.Len()
+func (v *MapView) Len() int {
+	return v.m.Len()
+}
+
+// See Map.Reduce()
+func (v *MapView) Reduce(startVal interface{}, f func(reduction interface{}, key, val interface{}) interface{}) interface{} {
+	return v.m.Reduce(startVal, f)
+}
+
+// impl String interface
+func (v *MapView) String() string {
+	return v.m.String()
+}
+
+// See Map.Vals()
+func (v *MapView) Vals() *Slice {
+	return v.m.Vals()
+}
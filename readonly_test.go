@@ -0,0 +1,61 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestSliceView(t *testing.T) {
+	var result int
+
+	convey.Convey("Live view", t, func() {
+		s := testSlice()
+		v := s.ReadOnly()
+		convey.So(v.Len(), convey.ShouldEqual, 6)
+		convey.So(v.Join(","), convey.ShouldEqual, "1,2,3,7,9,15")
+		s.Append(20)
+		convey.So(v.Len(), convey.ShouldEqual, 7)
+		v.Last(&result)
+		convey.So(result, convey.ShouldEqual, 20)
+		v.Get(-2, &result)
+		convey.So(result, convey.ShouldEqual, 15)
+		convey.So(v.Contains(7), convey.ShouldBeTrue)
+		convey.So(v.IndexOf(9), convey.ShouldEqual, 4)
+		convey.So(v.String(), convey.ShouldEqual, s.String())
+	})
+
+	convey.Convey("Copies are independent", t, func() {
+		s := testSlice()
+		v := s.ReadOnly()
+		found := v.FindAll(func(i int, e interface{}) bool { return e.(int) > 5 })
+		found.Clear()
+		convey.So(v.Len(), convey.ShouldEqual, 6)
+		var raw []int
+		v.ToRange(0, 1, &raw)
+		raw[0] = 99
+		v.First(&result)
+		convey.So(result, convey.ShouldEqual, 1)
+	})
+}
+
+func TestMapView(t *testing.T) {
+	var result int
+
+	convey.Convey("Live view", t, func() {
+		m := testMap()
+		v := m.ReadOnly()
+		convey.So(v.Len(), convey.ShouldEqual, 3)
+		m.Set("d", 4)
+		convey.So(v.Get("d", &result), convey.ShouldBeTrue)
+		convey.So(result, convey.ShouldEqual, 4)
+		convey.So(v.Keys().Join(""), convey.ShouldEqual, "abcd")
+		m.Remove("a")
+		convey.So(v.ContainsKey("a"), convey.ShouldBeFalse)
+		convey.So(v.Join(","), convey.ShouldEqual, "b:2,c:3,d:4")
+		c := v.Clone()
+		c.Clear()
+		convey.So(v.IsEmpty(), convey.ShouldBeFalse)
+	})
+}
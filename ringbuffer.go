@@ -0,0 +1,277 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Behavior of a RingBuffer when an element is Put while it's already full
+type RingBufferMode int
+
+const (
+	// Overwrite the oldest element when full (default)
+	RingOverwrite RingBufferMode = iota
+	// Reject the new element when full (Put returns ErrRingBufferFull)
+	RingReject
+	// Block the caller until room is available (or the context is done)
+	// Take() also blocks until an element is available in this mode.
+	RingBlock
+)
+
+// Returned by Put when the buffer is full in RingReject mode
+var ErrRingBufferFull = errors.New("RingBuffer is full")
+
+// Returned by Take when the buffer is empty (RingOverwrite & RingReject modes)
+var ErrRingBufferEmpty = errors.New("RingBuffer is empty")
+
+// Fixed capacity FIFO ring buffer of "generic" elements
+// Safe for concurrent use, so can be used between producer / consumer goroutines
+// (typically in RingBlock mode)
+type RingBuffer struct {
+	mode  RingBufferMode
+	elems []interface{}
+	// index of the oldest element
+	head int
+	// number of elements currently held
+	size int
+
+	lock sync.Mutex
+	// closed (and replaced) whenever the content changes, used to wake up
+	// blocked callers, unlike sync.Cond it can be combined with a context.
+	changed chan struct{}
+}
+
+// Initialize a new empty ring buffer of the given capacity
+// Will panic if capacity is < 1
+func NewRingBuffer(capacity int, mode RingBufferMode) *RingBuffer {
+	if capacity < 1 {
+		panic(fmt.Sprintf("Invalid RingBuffer capacity: %d", capacity))
+	}
+	return &RingBuffer{
+		mode:    mode,
+		elems:   make([]interface{}, capacity),
+		changed: make(chan struct{}),
+	}
+}
+
+// Capacity of the buffer (fixed)
+func (r *RingBuffer) Cap() int {
+	return len(r.elems)
+}
+
+// Clear (empty) the buffer
+// Return the buffer pointer to allow method chaining.
+func (r *RingBuffer) Clear() *RingBuffer {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for i := range r.elems {
+		r.elems[i] = nil // let go of references
+	}
+	r.head = 0
+	r.size = 0
+	r.notify()
+	return r
+}
+
+// Does the buffer contain the given element (using reflect.DeepEqual)
+func (r *RingBuffer) Contains(elem interface{}) bool {
+	found := false
+	r.Each(func(i int, e interface{}) bool {
+		found = reflect.DeepEqual(e, elem)
+		return found
+	})
+	return found
+}
+
+// Apply the function to all the elements, from oldest to newest
+// The function works on a snapshot so it may safely call the buffer methods.
+// If the function returns true (stop), iteration will stop
+func (r *RingBuffer) Each(f func(int, interface{}) (stop bool)) {
+	for i, e := range r.snapshot() {
+		if f(i, e) {
+			return
+		}
+	}
+}
+
+// Set value of ptr to the element at logical index idx (0 = oldest)
+// If idx is negative then idx element from the end (-1 = newest)
+// Will panic if the index is out of bounds
+func (r *RingBuffer) Get(idx int, ptr interface{}) {
+	r.lock.Lock()
+	var err error
+	if idx, err = r.handleIndex(idx); err != nil {
+		r.lock.Unlock()
+		panic(err.Error())
+	}
+	elem := r.elems[(r.head+idx)%len(r.elems)]
+	r.lock.Unlock()
+	PtrToVal(ptr).Set(reflect.ValueOf(elem))
+}
+
+// Is this buffer empty
+func (r *RingBuffer) IsEmpty() bool {
+	return r.Len() == 0
+}
+
+// Is this buffer full (Len() == Cap())
+func (r *RingBuffer) IsFull() bool {
+	return r.Len() == len(r.elems)
+}
+
+// Number of elements currently in the buffer
+func (r *RingBuffer) Len() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.size
+}
+
+// The mode this buffer was created with
+func (r *RingBuffer) Mode() RingBufferMode {
+	return r.mode
+}
+
+// Set value of ptr to the oldest element without removing it
+// Return ErrRingBufferEmpty if the buffer is empty (never blocks)
+func (r *RingBuffer) Peek(ptr interface{}) error {
+	r.lock.Lock()
+	if r.size == 0 {
+		r.lock.Unlock()
+		return ErrRingBufferEmpty
+	}
+	elem := r.elems[r.head]
+	r.lock.Unlock()
+	PtrToVal(ptr).Set(reflect.ValueOf(elem))
+	return nil
+}
+
+// Add an element as the newest item of the buffer
+// When full: RingOverwrite drops the oldest element, RingReject returns
+// ErrRingBufferFull and RingBlock waits until room is available.
+func (r *RingBuffer) Put(elem interface{}) error {
+	return r.PutContext(context.Background(), elem)
+}
+
+// Same as Put() but in RingBlock mode gives up waiting when ctx is done
+// in which case the context error is returned.
+func (r *RingBuffer) PutContext(ctx context.Context, elem interface{}) error {
+	r.lock.Lock()
+	for r.size == len(r.elems) {
+		switch r.mode {
+		case RingOverwrite:
+			r.elems[r.head] = nil
+			r.head = (r.head + 1) % len(r.elems)
+			r.size--
+		case RingReject:
+			r.lock.Unlock()
+			return ErrRingBufferFull
+		default:
+			if err := r.wait(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	r.elems[(r.head+r.size)%len(r.elems)] = elem
+	r.size++
+	r.notify()
+	r.lock.Unlock()
+	return nil
+}
+
+// Empty the buffer (impl Clearable)
+func (r *RingBuffer) Reset() {
+	r.Clear()
+}
+
+// impl String interface
+func (r *RingBuffer) String() string {
+	snapshot := r.snapshot()
+	return fmt.Sprintf("RingBuffer[%d/%d] %v", len(snapshot), len(r.elems), snapshot)
+}
+
+// Remove the oldest element and set ptr to its value
+// In RingBlock mode waits for an element to be available, otherwise returns
+// ErrRingBufferEmpty if the buffer is empty.
+func (r *RingBuffer) Take(ptr interface{}) error {
+	return r.TakeContext(context.Background(), ptr)
+}
+
+// Same as Take() but in RingBlock mode gives up waiting when ctx is done
+// in which case the context error is returned.
+func (r *RingBuffer) TakeContext(ctx context.Context, ptr interface{}) error {
+	r.lock.Lock()
+	for r.size == 0 {
+		if r.mode != RingBlock {
+			r.lock.Unlock()
+			return ErrRingBufferEmpty
+		}
+		if err := r.wait(ctx); err != nil {
+			return err
+		}
+	}
+	elem := r.elems[r.head]
+	r.elems[r.head] = nil
+	r.head = (r.head + 1) % len(r.elems)
+	r.size--
+	r.notify()
+	r.lock.Unlock()
+	PtrToVal(ptr).Set(reflect.ValueOf(elem))
+	return nil
+}
+
+// Copy the elements, from oldest to newest, into a new Slice
+func (r *RingBuffer) ToSlice() *Slice {
+	s := NewSlice()
+	s.slice = r.snapshot()
+	return s
+}
+
+// Validate the logical index is in the buffer bounds
+// Also turn negative indexes into index from the end (-1 = newest)
+// Lock must be held
+func (r *RingBuffer) handleIndex(idx int) (int, error) {
+	if idx < 0 {
+		idx = r.size + idx
+	}
+	if idx >= r.size || idx < 0 {
+		return idx, errors.New(fmt.Sprintf("Invalid RingBuffer index: %d", idx))
+	}
+	return idx, nil
+}
+
+// Wake up all blocked callers, lock must be held
+func (r *RingBuffer) notify() {
+	close(r.changed)
+	r.changed = make(chan struct{})
+}
+
+// Copy of the elements in logical order
+func (r *RingBuffer) snapshot() []interface{} {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	elems := make([]interface{}, r.size)
+	for i := range elems {
+		elems[i] = r.elems[(r.head+i)%len(r.elems)]
+	}
+	return elems
+}
+
+// Wait for the content to change or ctx to be done
+// Lock must be held when called, it's held again on return unless an error
+// is returned (in which case it's been released)
+func (r *RingBuffer) wait(ctx context.Context) error {
+	changed := r.changed
+	r.lock.Unlock()
+	select {
+	case <-changed:
+		r.lock.Lock()
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,96 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"context"
+	"fmt"
+	"github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestRingBuffer(t *testing.T) {
+	var result int
+
+	convey.Convey("Overwrite", t, func() {
+		r := NewRingBuffer(3, RingOverwrite)
+		convey.So(r.IsEmpty(), convey.ShouldBeTrue)
+		for i := 1; i <= 5; i++ {
+			convey.So(r.Put(i), convey.ShouldBeNil)
+		}
+		convey.So(r.IsFull(), convey.ShouldBeTrue)
+		convey.So(r.Len(), convey.ShouldEqual, 3)
+		convey.So(r.ToSlice().Join(","), convey.ShouldEqual, "3,4,5")
+		r.Get(0, &result)
+		convey.So(result, convey.ShouldEqual, 3)
+		r.Get(-1, &result)
+		convey.So(result, convey.ShouldEqual, 5)
+		convey.So(func() { r.Get(3, &result) }, convey.ShouldPanic)
+		convey.So(r.String(), convey.ShouldEqual, "RingBuffer[3/3] [3 4 5]")
+	})
+
+	convey.Convey("Reject", t, func() {
+		r := NewRingBuffer(2, RingReject)
+		convey.So(r.Put(1), convey.ShouldBeNil)
+		convey.So(r.Put(2), convey.ShouldBeNil)
+		convey.So(r.Put(3), convey.ShouldEqual, ErrRingBufferFull)
+		convey.So(r.Take(&result), convey.ShouldBeNil)
+		convey.So(result, convey.ShouldEqual, 1)
+		convey.So(r.Put(3), convey.ShouldBeNil)
+		convey.So(r.ToSlice().Join(","), convey.ShouldEqual, "2,3")
+		r.Clear()
+		convey.So(r.Take(&result), convey.ShouldEqual, ErrRingBufferEmpty)
+		convey.So(r.Peek(&result), convey.ShouldEqual, ErrRingBufferEmpty)
+	})
+
+	convey.Convey("Each", t, func() {
+		r := NewRingBuffer(4, RingOverwrite)
+		for i := 1; i <= 6; i++ {
+			r.Put(i)
+		}
+		a := ""
+		r.Each(func(i int, e interface{}) bool {
+			a = fmt.Sprintf("%s%d:%d ", a, i, e.(int))
+			return e == 5
+		})
+		convey.So(a, convey.ShouldEqual, "0:3 1:4 2:5 ")
+		r.Peek(&result)
+		convey.So(result, convey.ShouldEqual, 3)
+		convey.So(r.Len(), convey.ShouldEqual, 4)
+	})
+
+	convey.Convey("Blocking", t, func() {
+		r := NewRingBuffer(2, RingBlock)
+		done := make(chan int)
+		go func() {
+			sum := 0
+			var v int
+			for i := 0; i != 10; i++ {
+				r.Take(&v)
+				sum += v
+			}
+			done <- sum
+		}()
+		for i := 1; i <= 10; i++ {
+			convey.So(r.Put(i), convey.ShouldBeNil)
+		}
+		convey.So(<-done, convey.ShouldEqual, 55)
+	})
+
+	convey.Convey("Blocking cancellation", t, func() {
+		r := NewRingBuffer(1, RingBlock)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		convey.So(r.TakeContext(ctx, &result), convey.ShouldEqual, context.DeadlineExceeded)
+		r.Put(1)
+		ctx2, cancel2 := context.WithCancel(context.Background())
+		cancel2()
+		convey.So(r.PutContext(ctx2, 2), convey.ShouldEqual, context.Canceled)
+		convey.So(r.ToSlice().Join(","), convey.ShouldEqual, "1")
+	})
+
+	convey.Convey("Bad capacity", t, func() {
+		convey.So(func() { NewRingBuffer(0, RingReject) }, convey.ShouldPanic)
+	})
+}
@@ -14,12 +14,27 @@ import (
 // Custom "Generic" (Sorta) slice
 // Note: Satisfies sort.Interface so can use sort, search as long as Compare is
 // implemented
+//
+// Index model, shared by all the methods:
+//   - Element indexes (Get, Set, RemoveAt, Swap ...) must be in [-len, len-1],
+//     negative indexes count from the end (-1 = last).
+//   - Insert positions (Insert, InsertAll, InsertSlice) must be in [-len, len],
+//     the element is inserted before that position, so len appends.
+//   - Ranges (CloneRange, RemoveRange, ToRange) are inclusive, from and to are
+//     element indexes and from must not be after to. EachRange also accepts
+//     from after to, in which case it iterates in reverse order.
+//   - Whole slice methods (Each, Eachr, To) accept an empty slice, To then
+//     yields an empty slice.
+//
+// An invalid index or range causes a panic.
 type Slice struct {
 
 	// internal slice that hold the items
 	slice []interface{}
 	// value of pointer to slice
 	sliceValPtr reflect.Value
+	// change listeners, nil unless observed (See Subscribe)
+	obs *sliceObservers
 
 	// Returns whether two items are equal
 	// Default imlementation uses reflect.DeepEqual (==)
@@ -29,6 +44,12 @@ type Slice struct {
 	// **Nil by default**
 	// **MUST** be defined for sorting to work.
 	Compare func(a, b interface{}) int
+
+	// Optional hash function, equal elements **MUST** have the same hash
+	// **Nil by default**
+	// When defined the set operations (Distinct, Union ...) use hashing (O(n))
+	// rather than iteration (O(n²)). See DefaultHash().
+	Hash func(elem interface{}) uint64
 }
 
 // Initialize a new empty slice
@@ -62,21 +83,21 @@ func (s *Slice) Any(f func(interface{}) bool) bool {
 // Append a single value (in place)
 // Return the slice pointer to allow method chaining.
 func (s *Slice) Append(elem interface{}) *Slice {
-	s.slice = append(s.slice, elem)
+	s.insert(len(s.slice), []interface{}{elem})
 	return s
 }
 
 // Append several values (in place)
 // Return the slice pointer to allow method chaining.
 func (s *Slice) AppendAll(elems ...interface{}) *Slice {
-	s.slice = append(s.slice, elems...)
+	s.insert(len(s.slice), elems)
 	return s
 }
 
 // Append another Slice to this slice
 // Return the slice pointer to allow method chaining.
 func (s *Slice) AppendSlice(slice *Slice) *Slice {
-	s.slice = append(s.slice, slice.slice...)
+	s.insert(len(s.slice), slice.slice)
 	return s
 }
 
@@ -88,15 +109,16 @@ func (s *Slice) Cap() int {
 // Clear (empty) the list
 // Return the slice pointer to allow method chaining.
 func (s *Slice) Clear() *Slice {
-	// Note: A nil slice in go is valid and can then be used just as if empty
-	s.slice = nil
+	s.clear()
 	return s
 }
 
 // Create and return a clone of this slice
+// The clone holds the same elements (shallow copy, See DeepClone()) and uses
+// the same functions (Equals, Compare, Hash), listeners are not copied.
 func (s *Slice) Clone() *Slice {
-	clone := NewSlice()
-	clone.slice = append(clone.slice, s.slice)
+	clone := s.emptyCopy()
+	clone.slice = append(clone.slice, s.slice...)
 	return clone
 }
 
@@ -104,10 +126,7 @@ func (s *Slice) Clone() *Slice {
 // From and To are both inclusive
 func (s *Slice) CloneRange(from, to int) *Slice {
 	var err error
-	if from, err = s.handleIndex(from); err != nil {
-		panic(err.Error())
-	}
-	if to, err = s.handleIndex(to); err != nil {
+	if from, to, err = s.handleRange(from, to); err != nil {
 		panic(err.Error())
 	}
 	clone := NewSlice()
@@ -144,12 +163,15 @@ func (s *Slice) ContainsAny(elems ...interface{}) bool {
 // Apply the function to the whole slice (in order)
 // If the function returns true (stop), iteration will stop
 func (s *Slice) Each(f func(int, interface{}) (stop bool)) {
+	if len(s.slice) == 0 {
+		return
+	}
 	s.EachRange(0, len(s.slice)-1, f)
 }
 
 // Apply the function to the slice range
 // From and To are both inclusive
-// if from is < to it will iterate in reversed order
+// if from is > to it will iterate in reversed order
 // If the function returns true (stop), iteration will stop
 func (s *Slice) EachRange(from, to int, f func(int, interface{}) (stop bool)) {
 	var err error
@@ -180,15 +202,23 @@ func (s *Slice) EachRange(from, to int, f func(int, interface{}) (stop bool)) {
 // Apply the function to the whole slice (reverse order)
 // If the function returns true (stop), iteration will stop
 func (s *Slice) Eachr(f func(int, interface{}) (stop bool)) {
+	if len(s.slice) == 0 {
+		return
+	}
 	s.EachRange(len(s.slice)-1, 0, f)
 }
 
 // Fill(append to) the slice with 'count' times the 'elem' value (in place)
 // Return the slice pointer to allow method chaining.
 func (s *Slice) Fill(elem interface{}, count int) *Slice {
-	for i := 0; i != count; i++ {
-		s.Append(elem)
+	if count <= 0 {
+		return s
+	}
+	elems := make([]interface{}, count)
+	for i := range elems {
+		elems[i] = elem
 	}
+	s.insert(len(s.slice), elems)
 	return s
 }
 
@@ -257,38 +287,31 @@ func (s *Slice) IndexOf(elem interface{}) int {
 }
 
 // Insert the element before index idx
-// Can use negative index
+// Can use negative index, idx = Len() appends the element
 // Return the slice pointer to allow method chaining.
 func (s *Slice) Insert(idx int, elem interface{}) *Slice {
 	var err error
-	if idx, err = s.handleIndex(idx); err != nil {
+	if idx, err = s.handleInsertIndex(idx); err != nil {
 		panic(err.Error())
 	}
-	s.slice = append(s.slice, 0)
-	copy(s.slice[idx+1:], s.slice[idx:])
-	s.slice[idx] = elem
+	s.insert(idx, []interface{}{elem})
 	return s
 }
 
 // Insert All the element before index idx
-// Can use negative index
+// Can use negative index, idx = Len() appends the elements
 // Return the slice pointer to allow method chaining.
 func (s *Slice) InsertAll(idx int, elems ...interface{}) *Slice {
 	var err error
-	if idx, err = s.handleIndex(idx); err != nil {
+	if idx, err = s.handleInsertIndex(idx); err != nil {
 		panic(err.Error())
 	}
-	// Expand the slice by elems size
-	s.slice = append(s.slice, make([]interface{}, len(elems))...)
-	// Shift "in place" elements to the right of index to the right
-	copy(s.slice[idx+len(elems):], s.slice[idx:])
-	// fill in the space with the elements to be inserted
-	copy(s.slice[idx:], elems)
+	s.insert(idx, elems)
 	return s
 }
 
 // Insert All the element of the slice before index idx
-// Can use negative index
+// Can use negative index, idx = Len() appends the elements
 // Return the slice pointer to allow method chaining.
 func (s *Slice) InsertSlice(idx int, slice *Slice) *Slice {
 	s.InsertAll(idx, slice.slice...)
@@ -389,7 +412,7 @@ func (s *Slice) Peek(ptr interface{}) {
 func (s *Slice) Pop(ptr interface{}) {
 	s.Last(ptr)
 	// remove last elem of slice
-	s.slice = s.slice[:len(s.slice)-1]
+	s.remove(len(s.slice)-1, 1)
 }
 
 // Push an elem at the end of the slice (same as Append)
@@ -411,10 +434,14 @@ func (s *Slice) Reduce(startVal interface{}, f func(reduction interface{}, index
 }
 
 // Remove the element at the given index (in place)
+// Can use negative index
 // Return the slice pointer to allow method chaining.
 func (s *Slice) RemoveAt(idx int) *Slice {
-	copy(s.slice[idx:], s.slice[idx+1:]) // shift elements past index to the left
-	s.slice = s.slice[:len(s.slice)-1]   // lose last element
+	var err error
+	if idx, err = s.handleIndex(idx); err != nil {
+		panic(err.Error())
+	}
+	s.remove(idx, 1)
 	return s
 }
 
@@ -441,31 +468,44 @@ func (s *Slice) RemoveElems(elem interface{}) *Slice {
 func (s *Slice) RemoveFunc(f func(idx int, elem interface{}) bool) *Slice {
 	for i := 0; i < len(s.slice); i++ {
 		if f(i, s.slice[i]) {
+			size := len(s.slice)
 			s.RemoveAt(i)
-			i--
+			if len(s.slice) < size { // unless the removal was vetoed
+				i--
+			}
 		}
 	}
 	return s
 }
 
-// Remove the elements within the given index range
+// Remove the elements within the given index range (in place)
+// From and To are both inclusive
 // Return the slice pointer to allow method chaining.
 func (s *Slice) RemoveRange(from, to int) *Slice {
 	var err error
-	if from, err = s.handleIndex(from); err != nil {
-		panic(err.Error())
-	}
-	if to, err = s.handleIndex(to); err != nil {
+	if from, to, err = s.handleRange(from, to); err != nil {
 		panic(err.Error())
 	}
-	copy(s.slice[from:], s.slice[to:])         // shift elements
-	s.slice = s.slice[:len(s.slice)-(to-from)] // lose last elements
+	s.remove(from, to-from+1)
 	return s
 }
 
+// Empty the slice, a single SliceCleared when observed (impl Clearable)
+func (s *Slice) Reset() {
+	s.Clear()
+}
+
 // Reverse in place, the slice in place (first element becomes last etc...)
 // Return the slice pointer to allow method chaining.
 func (s *Slice) Reverse() *Slice {
+	if s.obs != nil {
+		perm := make([]int, len(s.slice))
+		for i := range perm {
+			perm[i] = len(perm) - 1 - i
+		}
+		s.reorder(0, perm)
+		return s
+	}
 	start := 0
 	end := len(s.slice) - 1
 	for end > start { // Otherwise 0 or 1 element left, nothing to swap
@@ -477,13 +517,19 @@ func (s *Slice) Reverse() *Slice {
 }
 
 // Set the element at the given index
+// Can use negative index
 // Return the slice pointer to allow method chaining.
 func (s *Slice) Set(idx int, elem interface{}) *Slice {
-	s.slice[idx] = elem
+	var err error
+	if idx, err = s.handleIndex(idx); err != nil {
+		panic(err.Error())
+	}
+	s.set(idx, elem)
 	return s
 }
 
 // Returns pointer to the raw underlying slice ([]interface{})
+// Note: Changes made through it are not reported to the listeners (See Subscribe)
 func (s *Slice) Slice() *[]interface{} {
 	return &s.slice
 }
@@ -504,14 +550,19 @@ func (s *Slice) Swap(a, b int) {
 		panic(err.Error())
 	}
 
-	s.slice[a], s.slice[b] = s.slice[b], s.slice[a]
+	s.swap(a, b)
 }
 
 // Export our "generic" slice to a typed slice (say []int)
 // Ptr needs to be a pointer to a slice
 // Note that it can't be a simple cast and instead the data needs to be copied
 // so it's definitely a VERY costly operation.
+// If the slice is empty, ptr is set to an empty slice.
 func (s *Slice) To(ptr interface{}) {
+	if len(s.slice) == 0 {
+		PtrToVal(ptr).Set(reflect.MakeSlice(reflect.TypeOf(ptr).Elem(), 0, 0))
+		return
+	}
 	s.ToRange(0, len(s.slice)-1, ptr)
 }
 
@@ -520,10 +571,7 @@ func (s *Slice) To(ptr interface{}) {
 // Note that from and to can use negative index to indicate "from the end"
 func (s *Slice) ToRange(from, to int, ptr interface{}) {
 	var err error
-	if from, err = s.handleIndex(from); err != nil {
-		panic(err.Error())
-	}
-	if to, err = s.handleIndex(to); err != nil {
+	if from, to, err = s.handleRange(from, to); err != nil {
 		panic(err.Error())
 	}
 
@@ -544,16 +592,47 @@ func (s *Slice) ToRange(from, to int, ptr interface{}) {
 	obj.Set(slice)
 }
 
-// Validate the index is in the slice bounds
-// Also turm negative indexes into index from the end of the slice (-1 = last)
+// Validate the element index is in the slice bounds [-len, len-1]
+// Also turn negative indexes into index from the end of the slice (-1 = last)
 func (s *Slice) handleIndex(idx int) (int, error) {
-	if idx < 0 {
-		idx = len(s.slice) + idx
+	i := idx
+	if i < 0 {
+		i = len(s.slice) + i
+	}
+	if i >= len(s.slice) || i < 0 {
+		return i, errors.New(fmt.Sprintf("Invalid slice index: %d", idx))
+	}
+	return i, nil
+}
+
+// Validate the insert position is in [-len, len] (len = after the last element)
+// Also turn negative positions into position from the end of the slice (-1 = before last)
+func (s *Slice) handleInsertIndex(idx int) (int, error) {
+	i := idx
+	if i < 0 {
+		i = len(s.slice) + i
+	}
+	if i > len(s.slice) || i < 0 {
+		return i, errors.New(fmt.Sprintf("Invalid slice index: %d", idx))
+	}
+	return i, nil
+}
+
+// Validate an inclusive range, both ends must be valid element indexes
+// and from must not be after to (once negative indexes are resolved)
+func (s *Slice) handleRange(from, to int) (int, int, error) {
+	var err error
+	f, t := from, to
+	if f, err = s.handleIndex(f); err != nil {
+		return f, t, err
+	}
+	if t, err = s.handleIndex(t); err != nil {
+		return f, t, err
 	}
-	if idx >= len(s.slice) || idx < 0 {
-		return idx, errors.New(fmt.Sprintf("Invalid slice index: %d", idx))
+	if f > t {
+		return f, t, errors.New(fmt.Sprintf("Invalid slice range: %d to %d", from, to))
 	}
-	return idx, nil
+	return f, t, nil
 }
 
 // Get the reflect.Value of the element pointed to by ptr
@@ -0,0 +1,274 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// Kind of edit in a Patch
+type EditOp int
+
+const (
+	// Element present in both slices
+	EditEqual EditOp = iota
+	// Element of the first slice, removed
+	EditDelete
+	// Element of the second slice, inserted
+	EditInsert
+)
+
+// An edit of a Patch (as returned by Diff())
+// AIndex is the index of the element in the first slice (or for inserts the
+// index it's inserted at), BIndex is the index in the second slice (or for
+// deletes where the following elements start).
+type Edit struct {
+	Op     EditOp
+	AIndex int
+	BIndex int
+	Elem   interface{}
+}
+
+// Edit script turning a slice into another one, in order
+type Patch []Edit
+
+// Compute the shortest edit script (Patch) turning a into b, using Myers' algorithm
+// Elements are compared with a.Equals
+// The patch includes the Equal edits as well so the full content of both slices
+// can be rendered.
+func Diff(a, b *Slice) Patch {
+	n, m := len(a.slice), len(b.slice)
+	max := n + m
+	// v[k+max] is the furthest x reached on diagonal k
+	v := make([]int, 2*max+2)
+	// v before each step d, for backtracking, only keeping the diagonals -d..d
+	// read by that step (so O(D²) memory rather than O((n+m)·D))
+	trace := [][]int{}
+	for d := 0; d <= max; d++ {
+		trace = append(trace, append([]int{}, v[max-d:max+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[k-1+max] < v[k+1+max]) {
+				x = v[k+1+max] // down: insert
+			} else {
+				x = v[k-1+max] + 1 // right: delete
+			}
+			y := x - k
+			for x < n && y < m && a.Equals(a.slice[x], b.slice[y]) {
+				x++
+				y++
+			}
+			v[k+max] = x
+			if x >= n && y >= m {
+				return backtrack(a, b, trace)
+			}
+		}
+	}
+	return nil
+}
+
+// Walk back the trace of Diff() to build the patch
+// trace[d][k+d] is the furthest x reached on diagonal k before step d
+func backtrack(a, b *Slice, trace [][]int) Patch {
+	x, y := len(a.slice), len(b.slice)
+	patch := Patch{}
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		prevK := 0 // start point
+		if d > 0 {
+			if k == -d || (k != d && v[k-1+d] < v[k+1+d]) {
+				prevK = k + 1
+			} else {
+				prevK = k - 1
+			}
+		}
+		prevX := v[prevK+d]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			patch = append(patch, Edit{EditEqual, x, y, a.slice[x]})
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			y--
+			patch = append(patch, Edit{EditInsert, x, y, b.slice[y]})
+		} else {
+			x--
+			patch = append(patch, Edit{EditDelete, x, y, a.slice[x]})
+		}
+	}
+	// reverse, the edits were collected from the end
+	for i, j := 0, len(patch)-1; i < j; i, j = i+1, j-1 {
+		patch[i], patch[j] = patch[j], patch[i]
+	}
+	return patch
+}
+
+// Apply the patch (in place), as returned by Diff(a, b) it turns a into b.
+// The Equal edits are optional, elements not in the patch are kept as is.
+// The deleted and Equal elements are checked (with Equals), an error is returned
+// and the slice is left untouched if they don't match.
+// If the slice is observed each edit is reported as a separate change, they
+// are all submitted to the before change listeners first (See OnBeforeChange),
+// if one is vetoed the slice is left untouched and the veto error returned.
+func (s *Slice) Apply(patch Patch) error {
+	results := make([]interface{}, 0, len(s.slice))
+	i := 0
+	for _, edit := range patch {
+		if edit.AIndex < i || edit.AIndex > len(s.slice) {
+			return errors.New(fmt.Sprintf("Invalid patch index: %d", edit.AIndex))
+		}
+		// unchanged elements not in the patch
+		results = append(results, s.slice[i:edit.AIndex]...)
+		i = edit.AIndex
+		switch edit.Op {
+		case EditInsert:
+			results = append(results, edit.Elem)
+			continue
+		case EditEqual, EditDelete:
+			if i == len(s.slice) || !s.Equals(s.slice[i], edit.Elem) {
+				return errors.New(fmt.Sprintf("Patch mismatch at index %d: expected %v", i, edit.Elem))
+			}
+			if edit.Op == EditEqual {
+				results = append(results, s.slice[i])
+			}
+			i++
+		default:
+			return errors.New(fmt.Sprintf("Invalid patch operation: %d", edit.Op))
+		}
+	}
+	if s.obs == nil {
+		s.slice = append(results, s.slice[i:]...)
+		return nil
+	}
+	// Observed: all or nothing, every change is submitted to the before change
+	// listeners before any is made
+	events := patch.events()
+	for _, event := range events {
+		if !s.obs.allow(event) {
+			return s.obs.lastVeto
+		}
+	}
+	s.slice = append(results, s.slice[i:]...)
+	for _, event := range events {
+		s.obs.notify(event)
+	}
+	return nil
+}
+
+// The changes made by the patch, as separate insert and remove events
+// (indexes being the ones at the time each edit is made, in order)
+func (p Patch) events() []SliceEvent {
+	events := []SliceEvent{}
+	pos, i := 0, 0
+	for _, edit := range p {
+		pos += edit.AIndex - i
+		i = edit.AIndex
+		switch edit.Op {
+		case EditInsert:
+			events = append(events, SliceInserted{Index: pos, Elems: []interface{}{edit.Elem}})
+			pos++
+		case EditEqual:
+			pos++
+			i++
+		case EditDelete:
+			events = append(events, SliceRemoved{Index: pos, Elems: []interface{}{edit.Elem}})
+			i++
+		}
+	}
+	return events
+}
+
+// Does the patch hold any Insert or Delete edit
+func (p Patch) HasChanges() bool {
+	for _, edit := range p {
+		if edit.Op != EditEqual {
+			return true
+		}
+	}
+	return false
+}
+
+// Render the patch as a unified diff: hunks of changes with context lines of
+// equal elements around them, elements formatted with %v, ie:
+//
+//	@@ -1,3 +1,3 @@
+//	 a
+//	-b
+//	+c
+//	 d
+//
+// A negative context renders the whole patch as a single hunk.
+func (p Patch) Unified(context int) string {
+	var buf bytes.Buffer
+	for from := 0; from < len(p); {
+		// find the next hunk: changes and up to context equal edits around them
+		start := from
+		for start < len(p) && p[start].Op == EditEqual {
+			start++
+		}
+		if start == len(p) {
+			break
+		}
+		if context >= 0 && start-context > from {
+			from = start - context
+		}
+		end := start
+		for equals := 0; end < len(p); end++ {
+			if p[end].Op != EditEqual {
+				equals = 0
+			} else if equals++; context >= 0 && equals > 2*context {
+				break
+			}
+		}
+		// trim the trailing context
+		trailing := 0
+		for end-trailing > start && p[end-trailing-1].Op == EditEqual {
+			trailing++
+		}
+		if context >= 0 && trailing > context {
+			end -= trailing - context
+		}
+		p[from:end].writeHunk(&buf)
+		from = end
+	}
+	return buf.String()
+}
+
+// Write the edits as a hunk of a unified diff
+func (p Patch) writeHunk(buf *bytes.Buffer) {
+	aCount, bCount := 0, 0
+	for _, edit := range p {
+		if edit.Op != EditInsert {
+			aCount++
+		}
+		if edit.Op != EditDelete {
+			bCount++
+		}
+	}
+	// line numbers are 1 based, or the line before if the range is empty
+	aStart, bStart := p[0].AIndex+1, p[0].BIndex+1
+	if aCount == 0 {
+		aStart--
+	}
+	if bCount == 0 {
+		bStart--
+	}
+	buf.WriteString(fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", aStart, aCount, bStart, bCount))
+	for _, edit := range p {
+		prefix := " "
+		switch edit.Op {
+		case EditDelete:
+			prefix = "-"
+		case EditInsert:
+			prefix = "+"
+		}
+		buf.WriteString(fmt.Sprintf("%s%v\n", prefix, edit.Elem))
+	}
+}
@@ -0,0 +1,169 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"errors"
+	"github.com/smartystreets/goconvey/convey"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestSliceDiff(t *testing.T) {
+
+	convey.Convey("Diff", t, func() {
+		a := testDiffSlice("ABCABBA")
+		b := testDiffSlice("CBABAC")
+		patch := Diff(a, b)
+		convey.So(testDiffOps(patch), convey.ShouldEqual, "-A-B C+B A B-B A+C")
+		convey.So(patch.HasChanges(), convey.ShouldBeTrue)
+		convey.So(patch[0], convey.ShouldResemble, Edit{EditDelete, 0, 0, "A"})
+		convey.So(patch[3], convey.ShouldResemble, Edit{EditInsert, 3, 1, "B"})
+		convey.So(patch[len(patch)-1], convey.ShouldResemble, Edit{EditInsert, 7, 5, "C"})
+		convey.So(a.Apply(patch), convey.ShouldBeNil)
+		convey.So(a.Join(""), convey.ShouldEqual, "CBABAC")
+		// Same content
+		patch = Diff(b, testDiffSlice("CBABAC"))
+		convey.So(patch.HasChanges(), convey.ShouldBeFalse)
+		convey.So(len(patch), convey.ShouldEqual, 6)
+		convey.So(len(Diff(NewSlice(), NewSlice())), convey.ShouldEqual, 0)
+		convey.So(testDiffOps(Diff(NewSlice(), b)), convey.ShouldEqual, "+C+B+A+B+A+C")
+		convey.So(testDiffOps(Diff(b, NewSlice())), convey.ShouldEqual, "-C-B-A-B-A-C")
+	})
+
+	convey.Convey("Large different slices", t, func() {
+		a, b := NewSlice(), NewSlice()
+		for i := 0; i != 1000; i++ {
+			a.Append(i)
+			b.Append(-i - 1)
+		}
+		patch := Diff(a, b)
+		convey.So(len(patch), convey.ShouldEqual, 2000)
+		convey.So(a.Apply(patch), convey.ShouldBeNil)
+		convey.So(a.Join(","), convey.ShouldEqual, b.Join(","))
+	})
+
+	convey.Convey("Diff uses Equals", t, func() {
+		a := NewSlice().AppendAll("a", "B")
+		a.Equals = func(x, y interface{}) bool { return strings.EqualFold(x.(string), y.(string)) }
+		convey.So(Diff(a, NewSlice().AppendAll("A", "b")).HasChanges(), convey.ShouldBeFalse)
+	})
+
+	convey.Convey("Random diffs", t, func() {
+		rng := rand.New(rand.NewSource(3))
+		for i := 0; i != 300; i++ {
+			a, b := testRandomDiffSlice(rng), testRandomDiffSlice(rng)
+			patch := Diff(a, b)
+			changes := 0
+			for _, edit := range patch {
+				if edit.Op != EditEqual {
+					changes++
+				}
+			}
+			// shortest edit script
+			convey.So(changes, convey.ShouldEqual, a.Len()+b.Len()-2*testLcs(a, b))
+			convey.So(a.Apply(patch), convey.ShouldBeNil)
+			convey.So(a.Join(""), convey.ShouldEqual, b.Join(""))
+		}
+	})
+
+	convey.Convey("Apply", t, func() {
+		s := testDiffSlice("abcd")
+		// sparse patch, without the Equal edits
+		patch := Patch{{EditDelete, 1, 1, "b"}, {EditInsert, 4, 3, "e"}}
+		convey.So(s.Apply(patch), convey.ShouldBeNil)
+		convey.So(s.Join(""), convey.ShouldEqual, "acde")
+		// mismatch
+		err := s.Apply(Patch{{EditDelete, 0, 0, "z"}})
+		convey.So(err, convey.ShouldNotBeNil)
+		convey.So(s.Apply(Patch{{EditEqual, 9, 0, "a"}}), convey.ShouldNotBeNil)
+		convey.So(s.Apply(Patch{{EditDelete, 4, 0, "a"}}), convey.ShouldNotBeNil)
+		convey.So(s.Join(""), convey.ShouldEqual, "acde")
+	})
+
+	convey.Convey("Apply vetoed", t, func() {
+		s := testDiffSlice("abcd")
+		errNoE := errors.New("no e")
+		s.OnBeforeChange(func(e SliceEvent) error {
+			if ins, ok := e.(SliceInserted); ok && ins.Elems[0] == "e" {
+				return errNoE
+			}
+			return nil
+		})
+		events := 0
+		s.Subscribe(func(e SliceEvent) { events++ })
+		patch := Diff(s, testDiffSlice("acef"))
+		convey.So(s.Apply(patch), convey.ShouldEqual, errNoE)
+		// all or nothing, even though edits come before the vetoed one
+		convey.So(s.Join(""), convey.ShouldEqual, "abcd")
+		convey.So(events, convey.ShouldEqual, 0)
+		convey.So(s.Apply(Diff(s, testDiffSlice("acd"))), convey.ShouldBeNil)
+		convey.So(s.Join(""), convey.ShouldEqual, "acd")
+		convey.So(events, convey.ShouldEqual, 1)
+	})
+
+	convey.Convey("Unified", t, func() {
+		a := testDiffSlice("abcdefghijklm")
+		b := testDiffSlice("abXdefghijkl")
+		patch := Diff(a, b)
+		convey.So(patch.Unified(1), convey.ShouldEqual,
+			"@@ -2,3 +2,3 @@\n b\n-c\n+X\n d\n@@ -12,2 +12,1 @@\n l\n-m\n")
+		convey.So(patch.Unified(0), convey.ShouldEqual,
+			"@@ -3,1 +3,1 @@\n-c\n+X\n@@ -13,1 +12,0 @@\n-m\n")
+		// close hunks are merged
+		convey.So(strings.Count(patch.Unified(5), "@@ -"), convey.ShouldEqual, 1)
+		convey.So(strings.Count(patch.Unified(-1), "\n"), convey.ShouldEqual, 15)
+		convey.So(Diff(a, a).Unified(3), convey.ShouldEqual, "")
+		convey.So(Diff(NewSlice(), testDiffSlice("ab")).Unified(3), convey.ShouldEqual,
+			"@@ -0,0 +1,2 @@\n+a\n+b\n")
+	})
+}
+
+// #################### TESTS DATA ####
+
+func testDiffSlice(str string) *Slice {
+	s := NewSlice()
+	for _, c := range str {
+		s.Append(string(c))
+	}
+	return s
+}
+
+func testRandomDiffSlice(rng *rand.Rand) *Slice {
+	b := make([]byte, rng.Intn(12))
+	for i := range b {
+		b[i] = "abc"[rng.Intn(3)]
+	}
+	return testDiffSlice(string(b))
+}
+
+// Patch as "-a+b c" (op & element)
+func testDiffOps(patch Patch) string {
+	ops := ""
+	for _, edit := range patch {
+		ops += string(" -+"[edit.Op]) + edit.Elem.(string)
+	}
+	return strings.TrimSpace(ops)
+}
+
+// Longest common subsequence length
+func testLcs(a, b *Slice) int {
+	x, y := *a.Slice(), *b.Slice()
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] > lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	return lcs[0][0]
+}
@@ -0,0 +1,95 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"fmt"
+)
+
+// Group the elements by the key returned by f
+// Returns a Map of key -> *Slice of the elements with that key, the keys are
+// ordered by first occurrence and the elements keep their order.
+func (s *Slice) GroupBy(f func(interface{}) (key interface{})) *Map {
+	groups := NewMap()
+	for _, e := range s.slice {
+		key := f(e)
+		group, found := groups.get(key)
+		if !found {
+			group = NewSlice()
+			groups.Set(key, group)
+		}
+		g := group.(*Slice)
+		g.slice = append(g.slice, e)
+	}
+	return groups
+}
+
+// Split the slice in two new slices: the elements for which f returns true and the others
+// Both keep the elements order.
+func (s *Slice) Partition(f func(int, interface{}) bool) (matches, others *Slice) {
+	matches, others = NewSlice(), NewSlice()
+	for i, e := range s.slice {
+		if f(i, e) {
+			matches.slice = append(matches.slice, e)
+		} else {
+			others.slice = append(others.slice, e)
+		}
+	}
+	return matches, others
+}
+
+// Split the slice into chunks of (at most) size elements
+// Returns a new Slice of *Slice, the last chunk might be smaller.
+// Will panic if size < 1
+func (s *Slice) Chunk(size int) *Slice {
+	if size < 1 {
+		panic(fmt.Sprintf("Invalid chunk size: %d", size))
+	}
+	return s.windows(size, size, true)
+}
+
+// Sliding windows of size elements, each starting step elements after the previous one
+// ie: [1 2 3 4 5].Windowed(3, 1) -> [[1 2 3] [2 3 4] [3 4 5]]
+// Returns a new Slice of *Slice, only full windows are included.
+// Will panic if size or step < 1
+func (s *Slice) Windowed(size, step int) *Slice {
+	if size < 1 || step < 1 {
+		panic(fmt.Sprintf("Invalid window size or step: %d, %d", size, step))
+	}
+	return s.windows(size, step, false)
+}
+
+// Split the slice in between each consecutive elements for which f returns true
+// ie: [1 2 4 5 7].SplitWhen(prev+1 != cur) -> [[1 2] [4 5] [7]]
+// Returns a new Slice of *Slice (empty if this slice is empty).
+func (s *Slice) SplitWhen(f func(prev, cur interface{}) bool) *Slice {
+	results := NewSlice()
+	start := 0
+	for i := 1; i <= len(s.slice); i++ {
+		if i == len(s.slice) || f(s.slice[i-1], s.slice[i]) {
+			part := NewSlice()
+			part.slice = append(part.slice, s.slice[start:i]...)
+			results.slice = append(results.slice, part)
+			start = i
+		}
+	}
+	return results
+}
+
+// Copies of the size elements windows, step elements apart
+func (s *Slice) windows(size, step int, partial bool) *Slice {
+	results := NewSlice()
+	for from := 0; from < len(s.slice); from += step {
+		to := from + size
+		if to > len(s.slice) {
+			if !partial {
+				break
+			}
+			to = len(s.slice)
+		}
+		window := NewSlice()
+		window.slice = append(window.slice, s.slice[from:to]...)
+		results.slice = append(results.slice, window)
+	}
+	return results
+}
@@ -0,0 +1,52 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestSliceGroup(t *testing.T) {
+
+	convey.Convey("GroupBy", t, func() {
+		s := NewSlice().AppendAll("bob", "al", "joe", "ed", "jo", "alice")
+		groups := s.GroupBy(func(e interface{}) interface{} { return len(e.(string)) })
+		convey.So(groups.Keys().Join(","), convey.ShouldEqual, "3,2,5")
+		var group *Slice
+		groups.Get(2, &group)
+		convey.So(group.Join(","), convey.ShouldEqual, "al,ed,jo")
+		convey.So(NewSlice().GroupBy(func(e interface{}) interface{} { return e }).IsEmpty(), convey.ShouldBeTrue)
+	})
+
+	convey.Convey("Partition", t, func() {
+		evens, odds := testSlice().Partition(func(i int, e interface{}) bool { return e.(int)%2 == 0 })
+		convey.So(evens.Join(","), convey.ShouldEqual, "2")
+		convey.So(odds.Join(","), convey.ShouldEqual, "1,3,7,9,15")
+	})
+
+	convey.Convey("Chunk", t, func() {
+		s := testSlice()
+		convey.So(s.Chunk(4).String(), convey.ShouldEqual, "Slice[2] [Slice[4] [1 2 3 7] Slice[2] [9 15]]")
+		convey.So(s.Chunk(6).Len(), convey.ShouldEqual, 1)
+		convey.So(s.Chunk(10).Len(), convey.ShouldEqual, 1)
+		convey.So(NewSlice().Chunk(2).IsEmpty(), convey.ShouldBeTrue)
+		convey.So(func() { s.Chunk(0) }, convey.ShouldPanic)
+	})
+
+	convey.Convey("Windowed", t, func() {
+		s := NewSlice().AppendAll(1, 2, 3, 4, 5)
+		convey.So(s.Windowed(3, 1).String(), convey.ShouldEqual,
+			"Slice[3] [Slice[3] [1 2 3] Slice[3] [2 3 4] Slice[3] [3 4 5]]")
+		convey.So(s.Windowed(2, 2).String(), convey.ShouldEqual, "Slice[2] [Slice[2] [1 2] Slice[2] [3 4]]")
+		convey.So(s.Windowed(6, 1).IsEmpty(), convey.ShouldBeTrue)
+		convey.So(func() { s.Windowed(2, 0) }, convey.ShouldPanic)
+	})
+
+	convey.Convey("SplitWhen", t, func() {
+		s := NewSlice().AppendAll(1, 2, 4, 5, 7)
+		parts := s.SplitWhen(func(prev, cur interface{}) bool { return prev.(int)+1 != cur.(int) })
+		convey.So(parts.String(), convey.ShouldEqual, "Slice[3] [Slice[2] [1 2] Slice[2] [4 5] Slice[1] [7]]")
+		convey.So(NewSlice().SplitWhen(func(prev, cur interface{}) bool { return true }).IsEmpty(), convey.ShouldBeTrue)
+	})
+}
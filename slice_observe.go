@@ -0,0 +1,255 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+// Observable slices
+// Once a listener is registered (Subscribe or OnBeforeChange) every change made
+// by the mutating methods (Append, InsertAll, RemoveRange, Set, Clear, Reverse ...)
+// is reported as one or more SliceEvent.
+// Note: Changes made directly to the raw slice (See Slice()) are not reported.
+
+// Change made to a slice, one of: SliceInserted, SliceRemoved, SliceSet,
+// SliceCleared, SliceReordered or SliceSwapped
+type SliceEvent interface {
+	sliceEvent()
+}
+
+// Elems were inserted at Index
+type SliceInserted struct {
+	Index int
+	Elems []interface{}
+}
+
+// Elems were removed from Index
+type SliceRemoved struct {
+	Index int
+	Elems []interface{}
+}
+
+// The element at Index was replaced
+type SliceSet struct {
+	Index int
+	Old   interface{}
+	New   interface{}
+}
+
+// The slice was cleared, Elems are the elements it held
+type SliceCleared struct {
+	Elems []interface{}
+}
+
+// The elements from Index were reordered: the element now at Index+i was
+// previously at Index+Perm[i]
+type SliceReordered struct {
+	Index int
+	Perm  []int
+}
+
+// The elements at A and B were swapped
+type SliceSwapped struct {
+	A int
+	B int
+}
+
+func (SliceInserted) sliceEvent()  {}
+func (SliceRemoved) sliceEvent()   {}
+func (SliceSet) sliceEvent()       {}
+func (SliceCleared) sliceEvent()   {}
+func (SliceReordered) sliceEvent() {}
+func (SliceSwapped) sliceEvent()   {}
+
+// Listeners of an observable slice
+type sliceObservers struct {
+	before   []*sliceListener
+	after    []*sliceListener
+	lastVeto error
+}
+
+type sliceListener struct {
+	before func(SliceEvent) error
+	after  func(SliceEvent)
+}
+
+// Returns the error of the last change attempt if it was vetoed (See OnBeforeChange)
+// nil if it went through (or if no listener is registered).
+func (s *Slice) LastVeto() error {
+	if s.obs == nil {
+		return nil
+	}
+	return s.obs.lastVeto
+}
+
+// Register a function called before each change, it can veto (cancel) the change
+// by returning an error, in which case the mutating method does not perform it
+// (See LastVeto())
+// Note: Methods making several changes (ie: RemoveFunc) only skip the vetoed ones,
+// except Apply and Transaction which are all or nothing.
+// Returns a function that unregisters it.
+func (s *Slice) OnBeforeChange(f func(SliceEvent) (veto error)) (unsubscribe func()) {
+	listener := &sliceListener{before: f}
+	s.observers().before = append(s.obs.before, listener)
+	return func() {
+		s.obs.before = removeListener(s.obs.before, listener)
+	}
+}
+
+// Register a function called after each change
+// Returns a function that unregisters it.
+func (s *Slice) Subscribe(f func(SliceEvent)) (unsubscribe func()) {
+	listener := &sliceListener{after: f}
+	s.observers().after = append(s.obs.after, listener)
+	return func() {
+		s.obs.after = removeListener(s.obs.after, listener)
+	}
+}
+
+// Clear the elements (primitive)
+func (s *Slice) clear() {
+	var event SliceEvent
+	if s.obs != nil {
+		if event = (SliceCleared{Elems: s.slice}); !s.obs.allow(event) {
+			return
+		}
+	}
+	// Note: A nil slice in go is valid and can then be used just as if empty
+	s.slice = nil
+	s.obs.notify(event)
+}
+
+// Insert elems at idx (primitive)
+func (s *Slice) insert(idx int, elems []interface{}) {
+	if len(elems) == 0 {
+		return
+	}
+	var event SliceEvent
+	if s.obs != nil {
+		event = SliceInserted{Index: idx, Elems: append([]interface{}{}, elems...)}
+		if !s.obs.allow(event) {
+			return
+		}
+	}
+	if idx == len(s.slice) {
+		s.slice = append(s.slice, elems...)
+	} else {
+		// Expand the slice by elems size
+		s.slice = append(s.slice, make([]interface{}, len(elems))...)
+		// Shift "in place" elements to the right of index to the right
+		copy(s.slice[idx+len(elems):], s.slice[idx:])
+		// fill in the space with the elements to be inserted
+		copy(s.slice[idx:], elems)
+	}
+	s.obs.notify(event)
+}
+
+// Remove count elements from idx (primitive)
+func (s *Slice) remove(idx, count int) {
+	if count == 0 {
+		return
+	}
+	var event SliceEvent
+	if s.obs != nil {
+		event = SliceRemoved{Index: idx, Elems: append([]interface{}{}, s.slice[idx:idx+count]...)}
+		if !s.obs.allow(event) {
+			return
+		}
+	}
+	copy(s.slice[idx:], s.slice[idx+count:]) // shift elements past index to the left
+	for i := len(s.slice) - count; i < len(s.slice); i++ {
+		s.slice[i] = nil // don't hold on to the removed elements
+	}
+	s.slice = s.slice[:len(s.slice)-count]
+	s.obs.notify(event)
+}
+
+// Reorder the elements from idx, the element at idx+perm[i] moving to idx+i (primitive)
+func (s *Slice) reorder(idx int, perm []int) {
+	if len(perm) < 2 {
+		return
+	}
+	var event SliceEvent
+	if s.obs != nil {
+		if event = (SliceReordered{Index: idx, Perm: perm}); !s.obs.allow(event) {
+			return
+		}
+	}
+	old := append([]interface{}{}, s.slice[idx:idx+len(perm)]...)
+	for i, p := range perm {
+		s.slice[idx+i] = old[p]
+	}
+	s.obs.notify(event)
+}
+
+// Replace the element at idx (primitive)
+func (s *Slice) set(idx int, elem interface{}) {
+	var event SliceEvent
+	if s.obs != nil {
+		if event = (SliceSet{Index: idx, Old: s.slice[idx], New: elem}); !s.obs.allow(event) {
+			return
+		}
+	}
+	s.slice[idx] = elem
+	s.obs.notify(event)
+}
+
+// Swap the elements at a and b (primitive)
+func (s *Slice) swap(a, b int) {
+	var event SliceEvent
+	if s.obs != nil {
+		if a == b {
+			return
+		}
+		if event = (SliceSwapped{A: a, B: b}); !s.obs.allow(event) {
+			return
+		}
+	}
+	s.slice[a], s.slice[b] = s.slice[b], s.slice[a]
+	s.obs.notify(event)
+}
+
+func (s *Slice) observers() *sliceObservers {
+	if s.obs == nil {
+		s.obs = &sliceObservers{}
+	}
+	return s.obs
+}
+
+// Ask the before change listeners whether the change can be done
+func (o *sliceObservers) allow(event SliceEvent) bool {
+	o.lastVeto = nil
+	for _, listener := range o.before {
+		if err := listener.before(event); err != nil {
+			o.lastVeto = err
+			return false
+		}
+	}
+	return true
+}
+
+// Notify the listeners of a change (if observed)
+func (o *sliceObservers) notify(event SliceEvent) {
+	if o == nil {
+		return
+	}
+	for _, listener := range o.after {
+		listener.after(event)
+	}
+}
+
+func removeListener(listeners []*sliceListener, listener *sliceListener) []*sliceListener {
+	results := []*sliceListener{}
+	for _, l := range listeners {
+		if l != listener {
+			results = append(results, l)
+		}
+	}
+	return results
+}
+
+// Permutation leaving all the elements in place
+func identityPerm(size int) []int {
+	perm := make([]int, size)
+	for i := range perm {
+		perm[i] = i
+	}
+	return perm
+}
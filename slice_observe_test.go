@@ -0,0 +1,144 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"errors"
+	"github.com/smartystreets/goconvey/convey"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestSliceObserve(t *testing.T) {
+	var val int
+
+	convey.Convey("Events", t, func() {
+		s := NewSlice().AppendAll(1, 2, 3)
+		events := []SliceEvent{}
+		unsubscribe := s.Subscribe(func(e SliceEvent) { events = append(events, e) })
+		s.Append(4).InsertAll(0, 8, 9).Set(1, 7)
+		s.RemoveAt(0)
+		s.Swap(0, 2)
+		s.Pop(&val)
+		s.Clear()
+		convey.So(events, convey.ShouldResemble, []SliceEvent{
+			SliceInserted{Index: 3, Elems: []interface{}{4}},
+			SliceInserted{Index: 0, Elems: []interface{}{8, 9}},
+			SliceSet{Index: 1, Old: 9, New: 7},
+			SliceRemoved{Index: 0, Elems: []interface{}{8}},
+			SliceSwapped{A: 0, B: 2},
+			SliceRemoved{Index: 4, Elems: []interface{}{4}},
+			SliceCleared{Elems: []interface{}{2, 1, 7, 3}},
+		})
+		unsubscribe()
+		s.Append(1)
+		convey.So(len(events), convey.ShouldEqual, 7)
+	})
+
+	convey.Convey("FilterInPlace", t, func() {
+		s := NewSlice().AppendAll(1, 2, 3, 4, 5, 6, 7, 8)
+		events := []SliceEvent{}
+		s.Subscribe(func(e SliceEvent) { events = append(events, e) })
+		s.OnBeforeChange(func(e SliceEvent) error {
+			if e.(SliceRemoved).Elems[0] == 5 {
+				return errors.New("keep 5")
+			}
+			return nil
+		})
+		s.FilterInPlace(func(i int, e interface{}) bool { return e.(int)%2 == 0 })
+		convey.So(s.Join(","), convey.ShouldEqual, "2,4,5,6,8")
+		convey.So(events, convey.ShouldResemble, []SliceEvent{
+			SliceRemoved{Index: 0, Elems: []interface{}{1}},
+			SliceRemoved{Index: 1, Elems: []interface{}{3}},
+			SliceRemoved{Index: 4, Elems: []interface{}{7}},
+		})
+		raw := *s.Slice()
+		convey.So(raw[:cap(raw)][5], convey.ShouldBeNil)
+	})
+
+	convey.Convey("Replaying the events", t, func() {
+		rng := rand.New(rand.NewSource(5))
+		s := NewSlice().AppendAll(5, 3, 8, 1, 9, 2)
+		mirror := append([]interface{}{}, *s.Slice()...)
+		s.Subscribe(func(e SliceEvent) { mirror = testReplay(mirror, e) })
+		check := func() {
+			convey.So(mirror, convey.ShouldResemble, *s.Slice())
+		}
+		s.AppendSlice(NewSlice().AppendAll(7, 7))
+		check()
+		s.Fill(0, 3).Insert(-1, 4).Reverse()
+		check()
+		s.RemoveElems(7).RemoveElem(0).RemoveRange(1, 3)
+		check()
+		s.RemoveFunc(func(i int, e interface{}) bool { return e.(int) == 0 })
+		check()
+		s.AppendAll(4, 6, 1, 3).MapInPlace(func(i int, e interface{}) interface{} { return e.(int) * 2 })
+		check()
+		s.FilterInPlace(func(i int, e interface{}) bool { return i%3 != 1 })
+		check()
+		s.Shuffle(rng).NthElement(2)
+		check()
+		s.Compare = func(a, b interface{}) int { return naturalCompare(a, b) }
+		sort.Sort(s)
+		check()
+		convey.So(s.Apply(Diff(s, NewSlice().AppendAll(2, 6, 10, 18, 20))), convey.ShouldBeNil)
+		check()
+		convey.So(s.Join(","), convey.ShouldEqual, "2,6,10,18,20")
+	})
+
+	convey.Convey("Veto", t, func() {
+		s := NewSlice().AppendAll(1, 2, 3, 4)
+		errOdd := errors.New("can't remove odd numbers")
+		events := 0
+		s.Subscribe(func(e SliceEvent) { events++ })
+		stop := s.OnBeforeChange(func(e SliceEvent) error {
+			if removed, ok := e.(SliceRemoved); ok && removed.Elems[0].(int)%2 == 1 {
+				return errOdd
+			}
+			return nil
+		})
+		s.RemoveAt(0)
+		convey.So(s.LastVeto(), convey.ShouldEqual, errOdd)
+		convey.So(s.Len(), convey.ShouldEqual, 4)
+		s.Append(5)
+		convey.So(s.LastVeto(), convey.ShouldBeNil)
+		s.RemoveFunc(func(i int, e interface{}) bool { return true })
+		convey.So(s.Join(","), convey.ShouldEqual, "1,3,5")
+		s.Pop(&val)
+		convey.So(val, convey.ShouldEqual, 5)
+		convey.So(s.Len(), convey.ShouldEqual, 3)
+		s.FilterInPlace(func(i int, e interface{}) bool { return false })
+		convey.So(s.Len(), convey.ShouldEqual, 3)
+		convey.So(events, convey.ShouldEqual, 3)
+		stop()
+		s.Clear()
+		convey.So(s.IsEmpty(), convey.ShouldBeTrue)
+		convey.So(NewSlice().LastVeto(), convey.ShouldBeNil)
+	})
+}
+
+// #################### TESTS DATA ####
+
+// Apply an event to a plain slice
+func testReplay(elems []interface{}, event SliceEvent) []interface{} {
+	switch e := event.(type) {
+	case SliceInserted:
+		tail := append(append([]interface{}{}, e.Elems...), elems[e.Index:]...)
+		return append(elems[:e.Index], tail...)
+	case SliceRemoved:
+		return append(elems[:e.Index], elems[e.Index+len(e.Elems):]...)
+	case SliceSet:
+		elems[e.Index] = e.New
+	case SliceCleared:
+		return []interface{}{}
+	case SliceReordered:
+		old := append([]interface{}{}, elems[e.Index:e.Index+len(e.Perm)]...)
+		for i, p := range e.Perm {
+			elems[e.Index+i] = old[p]
+		}
+	case SliceSwapped:
+		elems[e.A], elems[e.B] = elems[e.B], elems[e.A]
+	}
+	return elems
+}
@@ -0,0 +1,139 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+)
+
+// Source of random numbers used by the random methods (Shuffle, Sample ...)
+// Both math/rand and math/rand/v2 *Rand implement it, so seeded generators can
+// be used for reproducible results.
+// A nil RandSource uses the math/rand global (default) source.
+type RandSource interface {
+	Uint64() uint64
+}
+
+// Set ptr to a random element of the slice
+// Will panic if slice is empty
+func (s *Slice) Random(ptr interface{}, rng RandSource) {
+	if s.IsEmpty() {
+		panic("Can't get a Random element of empty slice !")
+	}
+	PtrToVal(ptr).Set(reflect.ValueOf(s.slice[randIntn(rng, len(s.slice))]))
+}
+
+// Returns a new Slice of k distinct (by index) random elements of this slice,
+// in random order (sampling without replacement)
+// Will panic if k is negative or larger than the slice length.
+func (s *Slice) Sample(k int, rng RandSource) *Slice {
+	if k < 0 || k > len(s.slice) {
+		panic(fmt.Sprintf("Invalid sample size: %d", k))
+	}
+	elems := append([]interface{}{}, s.slice...)
+	// partial Fisher-Yates
+	for i := 0; i != k; i++ {
+		j := i + randIntn(rng, len(elems)-i)
+		elems[i], elems[j] = elems[j], elems[i]
+	}
+	results := NewSlice()
+	results.slice = elems[:k]
+	return results
+}
+
+// Same as Sample() but the chances of each element to be selected are
+// proportional to its weight (as returned by f).
+// Elements with a weight <= 0 are never selected, so less than k elements
+// might be returned.
+// Will panic if k is negative.
+func (s *Slice) SampleWeighted(k int, f func(interface{}) (weight float64), rng RandSource) *Slice {
+	if k < 0 {
+		panic(fmt.Sprintf("Invalid sample size: %d", k))
+	}
+	// Efraimidis-Spirakis: keep the k largest u^(1/weight)
+	type candidate struct {
+		elem interface{}
+		key  float64
+	}
+	candidates := []candidate{}
+	for _, e := range s.slice {
+		if w := f(e); w > 0 {
+			candidates = append(candidates, candidate{e, math.Pow(randFloat(rng), 1/w)})
+		}
+	}
+	sort.SliceStable(candidates, func(a, b int) bool {
+		return candidates[a].key > candidates[b].key
+	})
+	if k > len(candidates) {
+		k = len(candidates)
+	}
+	results := NewSlice()
+	for _, c := range candidates[:k] {
+		results.slice = append(results.slice, c.elem)
+	}
+	return results
+}
+
+// Shuffle the elements (in place) using Fisher-Yates
+// Return the slice pointer to allow method chaining.
+func (s *Slice) Shuffle(rng RandSource) *Slice {
+	perm := identityPerm(len(s.slice))
+	for i := len(perm) - 1; i > 0; i-- {
+		j := randIntn(rng, i+1)
+		perm[i], perm[j] = perm[j], perm[i]
+	}
+	s.reorder(0, perm)
+	return s
+}
+
+// Returns a new Slice of (up to) k random elements of the iterable, using
+// reservoir sampling: a single pass without knowing the number of elements
+// in advance.
+// The elements are in no particular order.
+// Will panic if k is negative.
+func Reservoir(it Iterable, k int, rng RandSource) *Slice {
+	if k < 0 {
+		panic(fmt.Sprintf("Invalid sample size: %d", k))
+	}
+	results := NewSlice()
+	seen := 0
+	it.Each(func(i int, e interface{}) bool {
+		seen++
+		if len(results.slice) < k {
+			results.slice = append(results.slice, e)
+		} else if j := randIntn(rng, seen); j < k {
+			results.slice[j] = e
+		}
+		return false
+	})
+	return results
+}
+
+// Random int in [0, n) (n > 0)
+func randIntn(rng RandSource, n int) int {
+	max := uint64(n)
+	// reject the values of the incomplete last range to avoid a modulo bias
+	limit := math.MaxUint64 - math.MaxUint64%max
+	for {
+		v := randUint64(rng)
+		if v < limit {
+			return int(v % max)
+		}
+	}
+}
+
+// Random float64 in [0, 1)
+func randFloat(rng RandSource) float64 {
+	return float64(randUint64(rng)>>11) / (1 << 53)
+}
+
+func randUint64(rng RandSource) uint64 {
+	if rng == nil {
+		return rand.Uint64()
+	}
+	return rng.Uint64()
+}
@@ -0,0 +1,95 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"github.com/smartystreets/goconvey/convey"
+	"math/rand"
+	randv2 "math/rand/v2"
+	"testing"
+)
+
+func TestSliceRandom(t *testing.T) {
+	var val int
+
+	convey.Convey("Shuffle", t, func() {
+		s := testSlice()
+		a := testSlice().Shuffle(rand.New(rand.NewSource(7)))
+		b := testSlice().Shuffle(rand.New(rand.NewSource(7)))
+		convey.So(a.Join(","), convey.ShouldEqual, b.Join(","))
+		convey.So(a.IsPermutationOf(s), convey.ShouldBeTrue)
+		c := testSlice().Shuffle(randv2.New(randv2.NewPCG(1, 2)))
+		convey.So(c.IsPermutationOf(s), convey.ShouldBeTrue)
+		convey.So(NewSlice().Shuffle(nil).IsEmpty(), convey.ShouldBeTrue)
+		// All permutations are reachable
+		seen := map[string]bool{}
+		rng := rand.New(rand.NewSource(1))
+		for i := 0; i != 200; i++ {
+			seen[NewSlice().AppendAll(1, 2, 3).Shuffle(rng).Join("")] = true
+		}
+		convey.So(len(seen), convey.ShouldEqual, 6)
+	})
+
+	convey.Convey("Random", t, func() {
+		s := testSlice()
+		s.Random(&val, nil)
+		convey.So(s.Contains(val), convey.ShouldBeTrue)
+		rng := rand.New(rand.NewSource(3))
+		counts := map[int]int{}
+		for i := 0; i != 600; i++ {
+			s.Random(&val, rng)
+			counts[val]++
+		}
+		convey.So(len(counts), convey.ShouldEqual, 6)
+		convey.So(func() { NewSlice().Random(&val, nil) }, convey.ShouldPanic)
+	})
+
+	convey.Convey("Sample", t, func() {
+		s := testSlice()
+		sample := s.Sample(4, rand.New(rand.NewSource(5)))
+		convey.So(sample.Len(), convey.ShouldEqual, 4)
+		convey.So(sample.Distinct().Len(), convey.ShouldEqual, 4)
+		convey.So(s.ContainsAll(*sample.Slice()...), convey.ShouldBeTrue)
+		convey.So(s.Sample(4, rand.New(rand.NewSource(5))).Join(","), convey.ShouldEqual, sample.Join(","))
+		convey.So(s.Join(","), convey.ShouldEqual, "1,2,3,7,9,15")
+		convey.So(s.Sample(6, nil).IsPermutationOf(s), convey.ShouldBeTrue)
+		convey.So(s.Sample(0, nil).IsEmpty(), convey.ShouldBeTrue)
+		convey.So(func() { s.Sample(7, nil) }, convey.ShouldPanic)
+	})
+
+	convey.Convey("SampleWeighted", t, func() {
+		s := NewSlice().AppendAll(1, 2, 3, 4)
+		weight := func(e interface{}) float64 { return float64(e.(int) - 1) }
+		rng := rand.New(rand.NewSource(9))
+		counts := map[int]int{}
+		for i := 0; i != 3000; i++ {
+			sample := s.SampleWeighted(1, weight, rng)
+			sample.First(&val)
+			counts[val]++
+		}
+		convey.So(counts[1], convey.ShouldEqual, 0)
+		convey.So(counts[2], convey.ShouldBeLessThan, counts[3])
+		convey.So(counts[3], convey.ShouldBeLessThan, counts[4])
+		convey.So(s.SampleWeighted(4, weight, rng).Len(), convey.ShouldEqual, 3)
+		convey.So(func() { s.SampleWeighted(-1, weight, rng) }, convey.ShouldPanic)
+	})
+
+	convey.Convey("Reservoir", t, func() {
+		rng := rand.New(rand.NewSource(11))
+		counts := map[int]int{}
+		s := testSlice()
+		for i := 0; i != 1200; i++ {
+			sample := Reservoir(s, 2, rng)
+			convey.So(sample.Len(), convey.ShouldEqual, 2)
+			sample.Each(func(i int, e interface{}) bool {
+				counts[e.(int)]++
+				return false
+			})
+		}
+		for _, e := range *s.Slice() {
+			convey.So(counts[e.(int)], convey.ShouldBeBetween, 300, 500)
+		}
+		convey.So(Reservoir(s, 10, nil).IsPermutationOf(s), convey.ShouldBeTrue)
+		convey.So(Reservoir(NewBitSet(), 2, nil).IsEmpty(), convey.ShouldBeTrue)
+	})
+}
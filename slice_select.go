@@ -0,0 +1,268 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"container/heap"
+	"fmt"
+	"math/bits"
+	"sort"
+)
+
+// Selection methods
+// Unless a comparator is given, those use the slice Compare function if set,
+// or else the natural ordering of the elements which works for numbers (any mix
+// of numeric types) and strings (will panic for other types).
+
+// Returns a new Slice of the k smallest elements, smallest first
+// cmp is the comparator to use (See Slice.Compare), nil to use the slice default.
+// Runs in O(n log(k)) and does not modify the slice.
+func (s *Slice) BottomK(k int, cmp func(a, b interface{}) int) *Slice {
+	if cmp == nil {
+		cmp = s.compareFunc()
+	}
+	return s.bottomK(k, cmp)
+}
+
+// Returns the index of the element with the largest key (as returned by f),
+// -1 if the slice is empty.
+// Keys are compared by natural ordering (numbers or strings)
+// The first one is returned in case of a tie.
+func (s *Slice) MaxBy(f func(interface{}) (key interface{})) int {
+	_, max := s.minMaxBy(f, naturalCompare)
+	return max
+}
+
+// Returns the index of the largest element, (-1 if the slice is empty)
+// The first one is returned in case of a tie.
+func (s *Slice) MaxIndex() int {
+	_, max := s.MinMax()
+	return max
+}
+
+// Returns the index of the element with the smallest key (as returned by f),
+// -1 if the slice is empty.
+// Keys are compared by natural ordering (numbers or strings)
+// The first one is returned in case of a tie.
+func (s *Slice) MinBy(f func(interface{}) (key interface{})) int {
+	min, _ := s.minMaxBy(f, naturalCompare)
+	return min
+}
+
+// Returns the index of the smallest element, (-1 if the slice is empty)
+// The first one is returned in case of a tie.
+func (s *Slice) MinIndex() int {
+	min, _ := s.MinMax()
+	return min
+}
+
+// Returns the indexes of both the smallest and largest elements in a single pass
+// Both are -1 if the slice is empty.
+// The first ones are returned in case of a tie.
+func (s *Slice) MinMax() (minIdx, maxIdx int) {
+	return s.minMaxBy(nil, s.compareFunc())
+}
+
+// Partially sort the slice (in place) so that the element at index n is the one
+// that would be there if the slice was sorted, with all the elements before it
+// being smaller or equal and all the ones after it larger or equal.
+// Runs in O(n) on average (introselect: quickselect falling back to sorting if
+// partitioning goes badly).
+// n can be negative (from the end), will panic if it's out of bounds.
+// Return the slice pointer to allow method chaining.
+func (s *Slice) NthElement(n int) *Slice {
+	var err error
+	if n, err = s.handleIndex(n); err != nil {
+		panic(err.Error())
+	}
+	cmp := s.compareFunc()
+	if s.obs == nil {
+		nthElement(&sortable{s.slice, cmp}, n)
+		return s
+	}
+	// Observed: work out the permutation to report it as a single change
+	perm := &permSortable{identityPerm(len(s.slice)), s.slice, cmp}
+	nthElement(perm, n)
+	s.reorder(0, perm.perm)
+	return s
+}
+
+// Returns a new Slice of the k largest elements, largest first
+// cmp is the comparator to use (See Slice.Compare), nil to use the slice default.
+// Runs in O(n log(k)) and does not modify the slice.
+func (s *Slice) TopK(k int, cmp func(a, b interface{}) int) *Slice {
+	if cmp == nil {
+		cmp = s.compareFunc()
+	}
+	return s.bottomK(k, func(a, b interface{}) int { return cmp(b, a) })
+}
+
+// k smallest elements according to cmp, using a bounded max heap
+func (s *Slice) bottomK(k int, cmp func(a, b interface{}) int) *Slice {
+	if k < 0 {
+		panic(fmt.Sprintf("Invalid k: %d", k))
+	}
+	// max heap: the root is the largest of the k smallest found so far
+	h := &sortable{cmp: func(a, b interface{}) int { return cmp(b, a) }}
+	for _, e := range s.slice {
+		if h.Len() < k {
+			heap.Push(h, e)
+		} else if k > 0 && cmp(e, h.elems[0]) < 0 {
+			h.elems[0] = e
+			heap.Fix(h, 0)
+		}
+	}
+	results := NewSlice()
+	results.slice = make([]interface{}, h.Len())
+	for i := len(results.slice) - 1; i >= 0; i-- {
+		results.slice[i] = heap.Pop(h)
+	}
+	return results
+}
+
+// The comparator to use: Compare if set, natural ordering otherwise
+func (s *Slice) compareFunc() func(a, b interface{}) int {
+	if s.Compare != nil {
+		return s.Compare
+	}
+	return naturalCompare
+}
+
+// Indexes of the min & max elements (or keys if f is not nil)
+func (s *Slice) minMaxBy(f func(interface{}) interface{}, cmp func(a, b interface{}) int) (minIdx, maxIdx int) {
+	if len(s.slice) == 0 {
+		return -1, -1
+	}
+	key := func(i int) interface{} {
+		if f == nil {
+			return s.slice[i]
+		}
+		return f(s.slice[i])
+	}
+	min, max := key(0), key(0)
+	for i := 1; i < len(s.slice); i++ {
+		k := key(i)
+		if cmp(k, min) < 0 {
+			min, minIdx = k, i
+		}
+		if cmp(k, max) > 0 {
+			max, maxIdx = k, i
+		}
+	}
+	return minIdx, maxIdx
+}
+
+// Introselect: move the nth element in place, smaller ones before it and larger
+// ones after it
+func nthElement(data sort.Interface, n int) {
+	from, to := 0, data.Len()-1
+	budget := 2 * bits.Len(uint(data.Len()))
+	for from < to {
+		if budget == 0 {
+			// partitioning goes badly, sort what's left instead
+			sort.Sort(&subSortable{data, from, to + 1})
+			return
+		}
+		budget--
+		p := partition(data, from, to)
+		switch {
+		case n < p:
+			to = p - 1
+		case n > p:
+			from = p + 1
+		default:
+			return
+		}
+	}
+}
+
+// Partition the from-to range around a (median of 3) pivot
+// Returns the final index of the pivot.
+func partition(data sort.Interface, from, to int) int {
+	mid := from + (to-from)/2
+	// median of 3, moved to the end
+	if data.Less(mid, from) {
+		data.Swap(mid, from)
+	}
+	if data.Less(to, from) {
+		data.Swap(to, from)
+	}
+	if data.Less(mid, to) {
+		data.Swap(mid, to)
+	}
+	store := from
+	for i := from; i < to; i++ {
+		if data.Less(i, to) {
+			data.Swap(i, store)
+			store++
+		}
+	}
+	data.Swap(store, to)
+	return store
+}
+
+// Compare numbers or strings by their natural ordering
+func naturalCompare(a, b interface{}) int {
+	if x, ok := toFloat(a); ok {
+		if y, ok := toFloat(b); ok {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	}
+	if x, ok := a.(string); ok {
+		if y, ok := b.(string); ok {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	}
+	panic(fmt.Sprintf("Can't compare %T and %T without a Compare function", a, b))
+}
+
+// Elements with a comparator, implements sort.Interface and heap.Interface
+type sortable struct {
+	elems []interface{}
+	cmp   func(a, b interface{}) int
+}
+
+func (s *sortable) Len() int           { return len(s.elems) }
+func (s *sortable) Less(a, b int) bool { return s.cmp(s.elems[a], s.elems[b]) < 0 }
+func (s *sortable) Swap(a, b int)      { s.elems[a], s.elems[b] = s.elems[b], s.elems[a] }
+func (s *sortable) Push(e interface{}) { s.elems = append(s.elems, e) }
+func (s *sortable) Pop() interface{} {
+	e := s.elems[len(s.elems)-1]
+	s.elems = s.elems[:len(s.elems)-1]
+	return e
+}
+
+// Permutation of elements, sorted by comparing the elements
+type permSortable struct {
+	perm  []int
+	elems []interface{}
+	cmp   func(a, b interface{}) int
+}
+
+func (p *permSortable) Len() int { return len(p.perm) }
+func (p *permSortable) Less(a, b int) bool {
+	return p.cmp(p.elems[p.perm[a]], p.elems[p.perm[b]]) < 0
+}
+func (p *permSortable) Swap(a, b int) { p.perm[a], p.perm[b] = p.perm[b], p.perm[a] }
+
+// Range (from-to, to excluded) of a sort.Interface
+type subSortable struct {
+	data     sort.Interface
+	from, to int
+}
+
+func (s *subSortable) Len() int           { return s.to - s.from }
+func (s *subSortable) Less(a, b int) bool { return s.data.Less(s.from+a, s.from+b) }
+func (s *subSortable) Swap(a, b int)      { s.data.Swap(s.from+a, s.from+b) }
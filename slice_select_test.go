@@ -0,0 +1,90 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"github.com/smartystreets/goconvey/convey"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestSliceSelect(t *testing.T) {
+
+	convey.Convey("MinBy & MaxBy", t, func() {
+		s := NewSlice().AppendAll(testStat{"a", 3}, testStat{"b", 1}, testStat{"c", 7}, testStat{"d", 1})
+		byVal := func(e interface{}) interface{} { return e.(testStat).val }
+		convey.So(s.MinBy(byVal), convey.ShouldEqual, 1)
+		convey.So(s.MaxBy(byVal), convey.ShouldEqual, 2)
+		byName := func(e interface{}) interface{} { return e.(testStat).name }
+		convey.So(s.MaxBy(byName), convey.ShouldEqual, 3)
+		convey.So(NewSlice().MinBy(byVal), convey.ShouldEqual, -1)
+	})
+
+	convey.Convey("MinMax, MinIndex & MaxIndex", t, func() {
+		s := NewSlice().AppendAll(5, 2.5, uint8(9), 2.5, -1, 9)
+		min, max := s.MinMax()
+		convey.So(min, convey.ShouldEqual, 4)
+		convey.So(max, convey.ShouldEqual, 2)
+		convey.So(s.MinIndex(), convey.ShouldEqual, 4)
+		convey.So(s.MaxIndex(), convey.ShouldEqual, 2)
+		min, max = NewSlice().MinMax()
+		convey.So(min, convey.ShouldEqual, -1)
+		convey.So(max, convey.ShouldEqual, -1)
+		words := NewSlice().AppendAll("pear", "apple", "zoo")
+		convey.So(words.MinIndex(), convey.ShouldEqual, 1)
+		// Compare takes precedence
+		words.Compare = func(a, b interface{}) int { return len(a.(string)) - len(b.(string)) }
+		convey.So(words.MinIndex(), convey.ShouldEqual, 2)
+		convey.So(func() { NewSlice().AppendAll(1, true).MinIndex() }, convey.ShouldPanic)
+	})
+
+	convey.Convey("TopK & BottomK", t, func() {
+		s := NewSlice().AppendAll(5, 1, 9, 3, 7, 9, 2)
+		convey.So(s.TopK(3, nil).Join(","), convey.ShouldEqual, "9,9,7")
+		convey.So(s.BottomK(3, nil).Join(","), convey.ShouldEqual, "1,2,3")
+		convey.So(s.TopK(10, nil).Len(), convey.ShouldEqual, 7)
+		convey.So(s.TopK(0, nil).IsEmpty(), convey.ShouldBeTrue)
+		convey.So(s.Join(","), convey.ShouldEqual, "5,1,9,3,7,9,2")
+		reversed := func(a, b interface{}) int { return naturalCompare(b, a) }
+		convey.So(s.BottomK(2, reversed).Join(","), convey.ShouldEqual, "9,9")
+		convey.So(func() { s.TopK(-1, nil) }, convey.ShouldPanic)
+	})
+
+	convey.Convey("NthElement", t, func() {
+		s := NewSlice().AppendAll(5, 1, 9, 3, 7, 9, 2)
+		var val int
+		s.NthElement(3).Get(3, &val)
+		convey.So(val, convey.ShouldEqual, 5)
+		s.NthElement(-1).Last(&val)
+		convey.So(val, convey.ShouldEqual, 9)
+		convey.So(func() { s.NthElement(7) }, convey.ShouldPanic)
+		// many duplicates: falls back to sorting
+		same := NewSlice().Fill(1, 1000).Append(0)
+		same.NthElement(0).First(&val)
+		convey.So(val, convey.ShouldEqual, 0)
+		rnd := rand.New(rand.NewSource(42))
+		for i := 0; i != 200; i++ {
+			vals := make([]int, rnd.Intn(50)+1)
+			for j := range vals {
+				vals[j] = rnd.Intn(20)
+			}
+			s := NewSlice()
+			for _, v := range vals {
+				s.Append(v)
+			}
+			n := rnd.Intn(len(vals))
+			s.NthElement(n)
+			sort.Ints(vals)
+			s.Get(n, &val)
+			convey.So(val, convey.ShouldEqual, vals[n])
+			for j := 0; j != s.Len(); j++ {
+				var other int
+				s.Get(j, &other)
+				if (j < n && other > val) || (j > n && other < val) {
+					t.Fatalf("Misplaced element %d at %d, nth: %d: %v", other, j, n, s)
+				}
+			}
+		}
+	})
+}
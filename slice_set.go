@@ -0,0 +1,161 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"reflect"
+)
+
+// Set operations
+// Elements are matched using Equals, and Hash when defined (much faster).
+// The results are new slices using this slice Equals and Hash functions, they
+// keep the order of the elements (first occurrence) and hold no duplicates.
+
+// Returns a new Slice made of the elements of this slice, without duplicates
+func (s *Slice) Distinct() *Slice {
+	return s.Union(nil)
+}
+
+// Returns a new Slice made of the elements of this slice whose key (as returned by f)
+// was not already seen, ie: only the first element for each key is kept.
+// Keys are compared with reflect.DeepEqual and hashed with DefaultHash()
+func (s *Slice) DistinctBy(f func(interface{}) (key interface{})) *Slice {
+	results := s.emptyCopy()
+	keys := newElemSet(func(a, b interface{}) bool { return reflect.DeepEqual(a, b) }, DefaultHash)
+	for _, e := range s.slice {
+		if keys.add(f(e)) {
+			results.slice = append(results.slice, e)
+		}
+	}
+	return results
+}
+
+// Returns a new Slice made of the elements of this slice that are not in other
+func (s *Slice) Except(other *Slice) *Slice {
+	excluded := s.newSet(other)
+	results := s.emptyCopy()
+	seen := s.newSet(nil)
+	for _, e := range s.slice {
+		if !excluded.contains(e) && seen.add(e) {
+			results.slice = append(results.slice, e)
+		}
+	}
+	return results
+}
+
+// Returns a new Slice made of the elements of this slice that are also in other
+func (s *Slice) Intersect(other *Slice) *Slice {
+	included := s.newSet(other)
+	results := s.emptyCopy()
+	seen := s.newSet(nil)
+	for _, e := range s.slice {
+		if included.contains(e) && seen.add(e) {
+			results.slice = append(results.slice, e)
+		}
+	}
+	return results
+}
+
+// Is this slice made of the same elements as other (same number of times) in any order
+func (s *Slice) IsPermutationOf(other *Slice) bool {
+	if len(s.slice) != len(other.slice) {
+		return false
+	}
+	counter := NewCounterFromSlice(s)
+	for _, e := range other.slice {
+		if counter.Count(e) == 0 {
+			return false
+		}
+		counter.Remove(e, 1)
+	}
+	return true
+}
+
+// Returns a new Slice made of the elements of this slice followed by the
+// elements of other that are not in this slice
+func (s *Slice) Union(other *Slice) *Slice {
+	results := s.emptyCopy()
+	seen := s.newSet(nil)
+	for _, e := range s.slice {
+		if seen.add(e) {
+			results.slice = append(results.slice, e)
+		}
+	}
+	if other != nil {
+		for _, e := range other.slice {
+			if seen.add(e) {
+				results.slice = append(results.slice, e)
+			}
+		}
+	}
+	return results
+}
+
+// New empty slice using the same functions as this one
+func (s *Slice) emptyCopy() *Slice {
+	results := NewSlice()
+	results.Equals, results.Compare, results.Hash = s.Equals, s.Compare, s.Hash
+	return results
+}
+
+// New set using this slice Equals & Hash, holding the elements of other (if not nil)
+func (s *Slice) newSet(other *Slice) *elemSet {
+	set := newElemSet(s.Equals, s.Hash)
+	if other != nil {
+		for _, e := range other.slice {
+			set.add(e)
+		}
+	}
+	return set
+}
+
+// Set of elements used by the set operations
+// Uses hash buckets when a hash function is available, iteration otherwise.
+type elemSet struct {
+	equals  func(a, b interface{}) bool
+	hash    func(elem interface{}) uint64
+	buckets map[uint64][]interface{}
+	// all elements, only used without a hash function
+	elems []interface{}
+}
+
+func newElemSet(equals func(a, b interface{}) bool, hash func(elem interface{}) uint64) *elemSet {
+	set := &elemSet{equals: equals, hash: hash}
+	if hash != nil {
+		set.buckets = map[uint64][]interface{}{}
+	}
+	return set
+}
+
+// Add the element to the set, return false if it was already in it
+func (set *elemSet) add(elem interface{}) bool {
+	if set.hash == nil {
+		if set.find(set.elems, elem) {
+			return false
+		}
+		set.elems = append(set.elems, elem)
+		return true
+	}
+	h := set.hash(elem)
+	if set.find(set.buckets[h], elem) {
+		return false
+	}
+	set.buckets[h] = append(set.buckets[h], elem)
+	return true
+}
+
+func (set *elemSet) contains(elem interface{}) bool {
+	if set.hash == nil {
+		return set.find(set.elems, elem)
+	}
+	return set.find(set.buckets[set.hash(elem)], elem)
+}
+
+func (set *elemSet) find(elems []interface{}, elem interface{}) bool {
+	for _, e := range elems {
+		if set.equals(e, elem) {
+			return true
+		}
+	}
+	return false
+}
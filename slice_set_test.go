@@ -0,0 +1,72 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"github.com/smartystreets/goconvey/convey"
+	"strings"
+	"testing"
+)
+
+func TestSliceSet(t *testing.T) {
+	for _, hash := range []func(interface{}) uint64{nil, DefaultHash} {
+		name := "Iteration"
+		if hash != nil {
+			name = "Hashing"
+		}
+		newSlice := func(elems ...interface{}) *Slice {
+			s := NewSlice().AppendAll(elems...)
+			s.Hash = hash
+			return s
+		}
+
+		convey.Convey(name+": Distinct", t, func() {
+			s := newSlice(3, 1, 3, 2, 1, 4)
+			d := s.Distinct()
+			convey.So(d.Join(","), convey.ShouldEqual, "3,1,2,4")
+			convey.So(s.Len(), convey.ShouldEqual, 6)
+			convey.So(newSlice().Distinct().IsEmpty(), convey.ShouldBeTrue)
+		})
+
+		convey.Convey(name+": DistinctBy", t, func() {
+			s := newSlice("Bob", "al", "bob", "AL", "joe")
+			d := s.DistinctBy(func(e interface{}) interface{} { return strings.ToLower(e.(string)) })
+			convey.So(d.Join(","), convey.ShouldEqual, "Bob,al,joe")
+		})
+
+		convey.Convey(name+": Union", t, func() {
+			a := newSlice(1, 2, 2, 3)
+			b := newSlice(4, 3, 5, 4)
+			convey.So(a.Union(b).Join(","), convey.ShouldEqual, "1,2,3,4,5")
+			convey.So(b.Union(a).Join(","), convey.ShouldEqual, "4,3,5,1,2")
+		})
+
+		convey.Convey(name+": Intersect & Except", t, func() {
+			a := newSlice(1, 2, 2, 3, 5)
+			b := newSlice(5, 2, 7)
+			convey.So(a.Intersect(b).Join(","), convey.ShouldEqual, "2,5")
+			convey.So(a.Except(b).Join(","), convey.ShouldEqual, "1,3")
+			convey.So(a.Intersect(newSlice()).IsEmpty(), convey.ShouldBeTrue)
+			convey.So(a.Except(newSlice()).Join(","), convey.ShouldEqual, "1,2,3,5")
+		})
+
+		convey.Convey(name+": IsPermutationOf", t, func() {
+			a := newSlice(1, 2, 2, 3)
+			convey.So(a.IsPermutationOf(newSlice(2, 3, 1, 2)), convey.ShouldBeTrue)
+			convey.So(a.IsPermutationOf(newSlice(2, 3, 1, 1)), convey.ShouldBeFalse)
+			convey.So(a.IsPermutationOf(newSlice(2, 3, 1)), convey.ShouldBeFalse)
+			convey.So(newSlice().IsPermutationOf(newSlice()), convey.ShouldBeTrue)
+		})
+	}
+
+	convey.Convey("Custom Equals", t, func() {
+		s := NewSlice().AppendAll("a", "A", "b")
+		s.Equals = func(a, b interface{}) bool { return strings.EqualFold(a.(string), b.(string)) }
+		convey.So(s.Distinct().Join(","), convey.ShouldEqual, "a,b")
+		s.Hash = func(e interface{}) uint64 { return DefaultHash(strings.ToLower(e.(string))) }
+		d := s.Distinct()
+		convey.So(d.Join(","), convey.ShouldEqual, "a,b")
+		convey.So(d.Hash, convey.ShouldNotBeNil)
+		convey.So(s.Except(NewSlice().Append("B")).Join(","), convey.ShouldEqual, "a")
+	})
+}
@@ -0,0 +1,227 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// Statistics on numeric slices
+// The elements can be any mix of numeric types (int*, uint*, float*, including
+// named types based on those), they are converted to float64, so very large
+// integers might lose precision.
+// An error is returned if an element is not numeric.
+
+// Error returned by the statistics requiring at least one element
+var ErrEmptySlice = errors.New("Slice is empty")
+
+// A bucket of an Histogram: number of values within Min and Max
+// Max is excluded, except for the last bucket.
+type HistogramBucket struct {
+	Min   float64
+	Max   float64
+	Count int
+}
+
+// Average (mean) of the elements
+func (s *Slice) Average() (float64, error) {
+	vals, err := s.numbers(nil)
+	if err != nil {
+		return 0, err
+	}
+	return average(vals)
+}
+
+// Average of the numbers returned by f for each element (ie: a struct field)
+func (s *Slice) AverageBy(f func(interface{}) interface{}) (float64, error) {
+	vals, err := s.numbers(f)
+	if err != nil {
+		return 0, err
+	}
+	return average(vals)
+}
+
+// Split the range of values (Min to Max) in buckets of the same width and count
+// the number of values in each of them.
+// Returns a Slice of HistogramBucket (in increasing order)
+// An error is returned if a value is not finite (NaN or ±Inf).
+func (s *Slice) Histogram(buckets int) (*Slice, error) {
+	if buckets < 1 {
+		return nil, errors.New(fmt.Sprintf("Invalid number of buckets: %d", buckets))
+	}
+	vals, err := s.numbers(nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(vals) == 0 {
+		return nil, ErrEmptySlice
+	}
+	min, max := vals[0], vals[0]
+	for _, v := range vals {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, errors.New(fmt.Sprintf("Invalid histogram value: %v", v))
+		}
+		min, max = math.Min(min, v), math.Max(max, v)
+	}
+	width := (max - min) / float64(buckets)
+	results := make([]HistogramBucket, buckets)
+	for i := range results {
+		results[i].Min = min + float64(i)*width
+		results[i].Max = min + float64(i+1)*width
+	}
+	results[buckets-1].Max = max
+	for _, v := range vals {
+		i := buckets - 1
+		// a range too wide for float64 (max - min = +Inf) gives NaN, left in the last bucket
+		if pos := (v - min) / width; width > 0 && pos < float64(buckets) {
+			i = int(pos)
+		}
+		results[i].Count++
+	}
+	histogram := NewSlice()
+	for _, bucket := range results {
+		histogram.slice = append(histogram.slice, bucket)
+	}
+	return histogram, nil
+}
+
+// Median of the elements
+// Average of the two middle values if there is an even number of elements.
+func (s *Slice) Median() (float64, error) {
+	return s.Percentile(50)
+}
+
+// Most frequent value (the first one found in case of a tie)
+func (s *Slice) Mode() (float64, error) {
+	vals, err := s.numbers(nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(vals) == 0 {
+		return 0, ErrEmptySlice
+	}
+	counts := map[float64]int{}
+	mode := vals[0]
+	for _, v := range vals {
+		counts[v]++
+		if counts[v] > counts[mode] {
+			mode = v
+		}
+	}
+	// Keep the first one found in case of a tie
+	for _, v := range vals {
+		if counts[v] == counts[mode] {
+			return v, nil
+		}
+	}
+	return mode, nil
+}
+
+// Value below which p percent of the values are (p within 0 to 100)
+// Uses linear interpolation between the closest ranks.
+func (s *Slice) Percentile(p float64) (float64, error) {
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		return 0, errors.New(fmt.Sprintf("Invalid percentile: %v", p))
+	}
+	vals, err := s.numbers(nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(vals) == 0 {
+		return 0, ErrEmptySlice
+	}
+	sort.Float64s(vals)
+	rank := p / 100 * float64(len(vals)-1)
+	low := int(math.Floor(rank))
+	if low == len(vals)-1 {
+		return vals[low], nil
+	}
+	return vals[low] + (rank-float64(low))*(vals[low+1]-vals[low]), nil
+}
+
+// (Population) standard deviation of the elements
+func (s *Slice) StdDev() (float64, error) {
+	variance, err := s.Variance()
+	return math.Sqrt(variance), err
+}
+
+// Sum of the elements (0 if empty)
+func (s *Slice) Sum() (float64, error) {
+	vals, err := s.numbers(nil)
+	return sum(vals), err
+}
+
+// Sum of the numbers returned by f for each element (ie: a struct field)
+func (s *Slice) SumBy(f func(interface{}) interface{}) (float64, error) {
+	vals, err := s.numbers(f)
+	return sum(vals), err
+}
+
+// (Population) variance of the elements
+func (s *Slice) Variance() (float64, error) {
+	vals, err := s.numbers(nil)
+	if err != nil {
+		return 0, err
+	}
+	mean, err := average(vals)
+	if err != nil {
+		return 0, err
+	}
+	variance := 0.0
+	for _, v := range vals {
+		variance += (v - mean) * (v - mean)
+	}
+	return variance / float64(len(vals)), nil
+}
+
+// The elements (or the results of f if not nil) as float64s
+func (s *Slice) numbers(f func(interface{}) interface{}) ([]float64, error) {
+	vals := make([]float64, len(s.slice))
+	for i, e := range s.slice {
+		if f != nil {
+			e = f(e)
+		}
+		v, ok := toFloat(e)
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("Non numeric value at index %d: %v (%T)", i, e, e))
+		}
+		vals[i] = v
+	}
+	return vals, nil
+}
+
+func average(vals []float64) (float64, error) {
+	if len(vals) == 0 {
+		return 0, ErrEmptySlice
+	}
+	return sum(vals) / float64(len(vals)), nil
+}
+
+func sum(vals []float64) float64 {
+	total := 0.0
+	for _, v := range vals {
+		total += v
+	}
+	return total
+}
+
+// Convert a numeric value to float64
+func toFloat(val interface{}) (float64, bool) {
+	if val == nil {
+		return 0, false
+	}
+	v := reflect.ValueOf(val)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,127 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"github.com/smartystreets/goconvey/convey"
+	"math"
+	"testing"
+)
+
+func TestSliceStats(t *testing.T) {
+
+	convey.Convey("Sum & Average", t, func() {
+		s := NewSlice().AppendAll(1, int8(2), uint16(3), float32(1.5), 2.5, testCelsius(2))
+		sum, err := s.Sum()
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(sum, convey.ShouldEqual, 12)
+		avg, err := s.Average()
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(avg, convey.ShouldEqual, 2)
+		sum, err = NewSlice().Sum()
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(sum, convey.ShouldEqual, 0)
+		_, err = NewSlice().Average()
+		convey.So(err, convey.ShouldEqual, ErrEmptySlice)
+	})
+
+	convey.Convey("Non numeric", t, func() {
+		s := NewSlice().AppendAll(1, "2", 3)
+		_, err := s.Sum()
+		convey.So(err.Error(), convey.ShouldEqual, "Non numeric value at index 1: 2 (string)")
+		for _, f := range []func() (float64, error){s.Average, s.Median, s.Mode, s.Variance, s.StdDev} {
+			_, err = f()
+			convey.So(err, convey.ShouldNotBeNil)
+		}
+		_, err = NewSlice().Append(nil).Sum()
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+
+	convey.Convey("Median & Percentile", t, func() {
+		s := NewSlice().AppendAll(7, 1, 3, 9)
+		median, _ := s.Median()
+		convey.So(median, convey.ShouldEqual, 5)
+		median, _ = s.Append(4).Median()
+		convey.So(median, convey.ShouldEqual, 4)
+		p, _ := s.Percentile(0)
+		convey.So(p, convey.ShouldEqual, 1)
+		p, _ = s.Percentile(100)
+		convey.So(p, convey.ShouldEqual, 9)
+		p, _ = s.Percentile(90)
+		convey.So(p, convey.ShouldAlmostEqual, 8.2)
+		_, err := s.Percentile(101)
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = NewSlice().Median()
+		convey.So(err, convey.ShouldEqual, ErrEmptySlice)
+		// not sorted in place
+		convey.So(s.Join(","), convey.ShouldEqual, "7,1,3,9,4")
+	})
+
+	convey.Convey("Variance, StdDev & Mode", t, func() {
+		s := NewSlice().AppendAll(2, 4, 4, 4, 5, 5, 7, 9)
+		variance, _ := s.Variance()
+		convey.So(variance, convey.ShouldEqual, 4)
+		stdDev, _ := s.StdDev()
+		convey.So(stdDev, convey.ShouldEqual, 2)
+		mode, _ := s.Mode()
+		convey.So(mode, convey.ShouldEqual, 4)
+		mode, _ = NewSlice().AppendAll(3, 1, 1, 3, 2.0).Mode()
+		convey.So(mode, convey.ShouldEqual, 3)
+		_, err := NewSlice().StdDev()
+		convey.So(err, convey.ShouldEqual, ErrEmptySlice)
+	})
+
+	convey.Convey("Histogram", t, func() {
+		s := NewSlice().AppendAll(0, 1, 2, 5, 9, 10)
+		h, err := s.Histogram(2)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(h.String(), convey.ShouldEqual, "Slice[2] [{0 5 3} {5 10 3}]")
+		h, _ = s.Histogram(5)
+		var bucket HistogramBucket
+		h.Last(&bucket)
+		convey.So(bucket, convey.ShouldResemble, HistogramBucket{Min: 8, Max: 10, Count: 2})
+		h, _ = NewSlice().AppendAll(3, 3).Histogram(3)
+		h.Last(&bucket)
+		convey.So(bucket.Count, convey.ShouldEqual, 2)
+		_, err = s.Histogram(0)
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = NewSlice().Histogram(1)
+		convey.So(err, convey.ShouldEqual, ErrEmptySlice)
+		_, err = NewSlice().AppendAll(1.0, 2.0, math.Inf(1)).Histogram(3)
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = NewSlice().AppendAll(math.Inf(-1), 2.0).Histogram(3)
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = NewSlice().AppendAll(1.0, math.NaN()).Histogram(3)
+		convey.So(err, convey.ShouldNotBeNil)
+		// range overflowing float64
+		h, err = NewSlice().AppendAll(-math.MaxFloat64, 0.0, math.MaxFloat64).Histogram(2)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(h.Len(), convey.ShouldEqual, 2)
+		total := 0
+		h.Each(func(i int, e interface{}) bool {
+			total += e.(HistogramBucket).Count
+			return false
+		})
+		convey.So(total, convey.ShouldEqual, 3)
+	})
+
+	convey.Convey("SumBy & AverageBy", t, func() {
+		s := NewSlice().AppendAll(testStat{"a", 3}, testStat{"b", 5})
+		sum, err := s.SumBy(func(e interface{}) interface{} { return e.(testStat).val })
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(sum, convey.ShouldEqual, 8)
+		avg, _ := s.AverageBy(func(e interface{}) interface{} { return e.(testStat).val })
+		convey.So(avg, convey.ShouldEqual, 4)
+		_, err = s.SumBy(func(e interface{}) interface{} { return e.(testStat).name })
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+}
+
+// #################### TESTS DATA ####
+
+type testCelsius float64
+
+type testStat struct {
+	name string
+	val  int
+}
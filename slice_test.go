@@ -200,8 +200,8 @@ func TestSlice(t *testing.T) {
 		s.AppendAll("D", "E", "A", "D", "B", "E", "E", "F")
 		s.RemoveAt(2)
 		convey.So(s.Join(""), convey.ShouldEqual, "DEDBEEF")
-		s.RemoveRange(1, -2)
-		convey.So(s.Join(""), convey.ShouldEqual, "DEF")
+		s.RemoveRange(1, -2) // inclusive
+		convey.So(s.Join(""), convey.ShouldEqual, "DF")
 		s.Clear()
 		s.AppendAll("T", "H", "I", "B", "A", "U", "T")
 		s.RemoveFunc(func(i int, e interface{}) bool {
@@ -428,6 +428,130 @@ func TestReduce(t *testing.T) {
 	})
 }
 
+func TestSliceIndexes(t *testing.T) {
+	// Table of index edge cases, run against "ABCDE"
+	// want is the resulting string, or the panic message when prefixed by "panic: "
+	get := func(idx int) func(s *Slice) string {
+		return func(s *Slice) string {
+			var result string
+			s.Get(idx, &result)
+			return result
+		}
+	}
+	each := func(from, to int) func(s *Slice) string {
+		return func(s *Slice) string {
+			a := ""
+			s.EachRange(from, to, func(i int, e interface{}) bool {
+				a += e.(string)
+				return false
+			})
+			return a
+		}
+	}
+	toRange := func(from, to int) func(s *Slice) string {
+		return func(s *Slice) string {
+			var results []string
+			s.ToRange(from, to, &results)
+			return strings.Join(results, "")
+		}
+	}
+	cases := []struct {
+		name string
+		op   func(s *Slice) string
+		want string
+	}{
+		{"Get(0)", get(0), "A"},
+		{"Get(4)", get(4), "E"},
+		{"Get(-1)", get(-1), "E"},
+		{"Get(-5)", get(-5), "A"},
+		{"Get(5)", get(5), "panic: Invalid slice index: 5"},
+		{"Get(-6)", get(-6), "panic: Invalid slice index: -6"},
+		{"Set(0)", func(s *Slice) string { return s.Set(0, "X").Join("") }, "XBCDE"},
+		{"Set(-1)", func(s *Slice) string { return s.Set(-1, "X").Join("") }, "ABCDX"},
+		{"Set(-5)", func(s *Slice) string { return s.Set(-5, "X").Join("") }, "XBCDE"},
+		{"Set(5)", func(s *Slice) string { return s.Set(5, "X").Join("") }, "panic: Invalid slice index: 5"},
+		{"Set(-6)", func(s *Slice) string { return s.Set(-6, "X").Join("") }, "panic: Invalid slice index: -6"},
+		{"RemoveAt(0)", func(s *Slice) string { return s.RemoveAt(0).Join("") }, "BCDE"},
+		{"RemoveAt(4)", func(s *Slice) string { return s.RemoveAt(4).Join("") }, "ABCD"},
+		{"RemoveAt(-2)", func(s *Slice) string { return s.RemoveAt(-2).Join("") }, "ABCE"},
+		{"RemoveAt(5)", func(s *Slice) string { return s.RemoveAt(5).Join("") }, "panic: Invalid slice index: 5"},
+		{"RemoveAt(-6)", func(s *Slice) string { return s.RemoveAt(-6).Join("") }, "panic: Invalid slice index: -6"},
+		{"Insert(0)", func(s *Slice) string { return s.Insert(0, "X").Join("") }, "XABCDE"},
+		{"Insert(4)", func(s *Slice) string { return s.Insert(4, "X").Join("") }, "ABCDXE"},
+		{"Insert(5)", func(s *Slice) string { return s.Insert(5, "X").Join("") }, "ABCDEX"},
+		{"Insert(-1)", func(s *Slice) string { return s.Insert(-1, "X").Join("") }, "ABCDXE"},
+		{"Insert(-5)", func(s *Slice) string { return s.Insert(-5, "X").Join("") }, "XABCDE"},
+		{"Insert(6)", func(s *Slice) string { return s.Insert(6, "X").Join("") }, "panic: Invalid slice index: 6"},
+		{"Insert(-6)", func(s *Slice) string { return s.Insert(-6, "X").Join("") }, "panic: Invalid slice index: -6"},
+		{"InsertAll(5)", func(s *Slice) string { return s.InsertAll(5, "X", "Y").Join("") }, "ABCDEXY"},
+		{"InsertAll(-2)", func(s *Slice) string { return s.InsertAll(-2, "X", "Y").Join("") }, "ABCXYDE"},
+		{"InsertSlice(5)", func(s *Slice) string { return s.InsertSlice(5, NewSlice().AppendAll("X")).Join("") }, "ABCDEX"},
+		{"RemoveRange(0, 4)", func(s *Slice) string { return s.RemoveRange(0, 4).Join("") }, ""},
+		{"RemoveRange(1, -2)", func(s *Slice) string { return s.RemoveRange(1, -2).Join("") }, "AE"},
+		{"RemoveRange(2, 2)", func(s *Slice) string { return s.RemoveRange(2, 2).Join("") }, "ABDE"},
+		{"RemoveRange(-2, -1)", func(s *Slice) string { return s.RemoveRange(-2, -1).Join("") }, "ABC"},
+		{"RemoveRange(3, 1)", func(s *Slice) string { return s.RemoveRange(3, 1).Join("") }, "panic: Invalid slice range: 3 to 1"},
+		{"RemoveRange(-1, -2)", func(s *Slice) string { return s.RemoveRange(-1, -2).Join("") }, "panic: Invalid slice range: -1 to -2"},
+		{"RemoveRange(0, 5)", func(s *Slice) string { return s.RemoveRange(0, 5).Join("") }, "panic: Invalid slice index: 5"},
+		{"RemoveRange(-6, 0)", func(s *Slice) string { return s.RemoveRange(-6, 0).Join("") }, "panic: Invalid slice index: -6"},
+		{"CloneRange(1, 3)", func(s *Slice) string { return s.CloneRange(1, 3).Join("") }, "BCD"},
+		{"CloneRange(-1, -1)", func(s *Slice) string { return s.CloneRange(-1, -1).Join("") }, "E"},
+		{"CloneRange(3, 1)", func(s *Slice) string { return s.CloneRange(3, 1).Join("") }, "panic: Invalid slice range: 3 to 1"},
+		{"ToRange(0, -1)", toRange(0, -1), "ABCDE"},
+		{"ToRange(-2, 4)", toRange(-2, 4), "DE"},
+		{"ToRange(4, 3)", toRange(4, 3), "panic: Invalid slice range: 4 to 3"},
+		{"ToRange(0, 5)", toRange(0, 5), "panic: Invalid slice index: 5"},
+		{"EachRange(1, 3)", each(1, 3), "BCD"},
+		{"EachRange(3, 1)", each(3, 1), "DCB"},
+		{"EachRange(-1, -5)", each(-1, -5), "EDCBA"},
+		{"EachRange(0, 5)", each(0, 5), "panic: Invalid slice index: 5"},
+		{"Swap(0, -1)", func(s *Slice) string { s.Swap(0, -1); return s.Join("") }, "EBCDA"},
+		{"Swap(0, 5)", func(s *Slice) string { s.Swap(0, 5); return s.Join("") }, "panic: Invalid slice index: 5"},
+	}
+	for _, c := range cases {
+		c := c
+		convey.Convey(c.name, t, func() {
+			s := NewSlice().AppendAll("A", "B", "C", "D", "E")
+			if strings.HasPrefix(c.want, "panic: ") {
+				convey.So(func() { c.op(s) }, convey.ShouldPanicWith, strings.TrimPrefix(c.want, "panic: "))
+				convey.So(s.Join(""), convey.ShouldEqual, "ABCDE")
+			} else {
+				convey.So(c.op(s), convey.ShouldEqual, c.want)
+			}
+		})
+	}
+
+	convey.Convey("Empty slice", t, func() {
+		s := NewSlice()
+		s.Each(func(i int, e interface{}) bool { panic("Each called on empty slice") })
+		s.Eachr(func(i int, e interface{}) bool { panic("Eachr called on empty slice") })
+		results := []string{"X"}
+		s.To(&results)
+		convey.So(results, convey.ShouldNotBeNil)
+		convey.So(len(results), convey.ShouldEqual, 0)
+		var result string
+		convey.So(func() { s.Get(0, &result) }, convey.ShouldPanicWith, "Invalid slice index: 0")
+		convey.So(func() { s.Get(-1, &result) }, convey.ShouldPanicWith, "Invalid slice index: -1")
+		convey.So(func() { s.First(&result) }, convey.ShouldPanic)
+		convey.So(func() { s.Last(&result) }, convey.ShouldPanic)
+		convey.So(func() { s.RemoveAt(0) }, convey.ShouldPanic)
+		convey.So(func() { s.Set(0, "X") }, convey.ShouldPanic)
+		convey.So(func() { s.RemoveRange(0, 0) }, convey.ShouldPanic)
+		convey.So(func() { s.EachRange(0, 0, func(i int, e interface{}) bool { return false }) }, convey.ShouldPanic)
+		convey.So(func() { s.Insert(-1, "X") }, convey.ShouldPanicWith, "Invalid slice index: -1")
+		convey.So(s.Insert(0, "X").Join(""), convey.ShouldEqual, "X")
+	})
+
+	convey.Convey("Observed RemoveRange", t, func() {
+		s := NewSlice().AppendAll("A", "B", "C", "D", "E")
+		var removed []interface{}
+		s.Subscribe(func(e SliceEvent) { removed = e.(SliceRemoved).Elems })
+		s.RemoveRange(1, 3)
+		convey.So(removed, convey.ShouldResemble, []interface{}{"B", "C", "D"})
+		convey.So(s.Join(""), convey.ShouldEqual, "AE")
+	})
+}
+
 // #################### BENCHMARKS ############################################
 
 func BenchmarkGenericSlice(b *testing.B) {
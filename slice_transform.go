@@ -0,0 +1,121 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+// Returns a new Slice made of the results of f applied to each element (in order)
+func (s *Slice) Map(f func(interface{}) interface{}) *Slice {
+	return s.MapIndexed(func(i int, e interface{}) interface{} {
+		return f(e)
+	})
+}
+
+// Same as Map() but the function is also given the element index
+func (s *Slice) MapIndexed(f func(int, interface{}) interface{}) *Slice {
+	results := NewSlice()
+	results.slice = make([]interface{}, len(s.slice))
+	for i, e := range s.slice {
+		results.slice[i] = f(i, e)
+	}
+	return results
+}
+
+// Returns a new Slice made of the concatenated results of f applied to each element
+// f can return a *Slice or an []interface{} whose elements are added (flattened),
+// nil (or a nil *Slice) which adds nothing, any other value is added as is.
+func (s *Slice) FlatMap(f func(interface{}) interface{}) *Slice {
+	results := NewSlice()
+	for _, e := range s.slice {
+		switch r := f(e).(type) {
+		case nil:
+		case *Slice:
+			if r != nil {
+				results.slice = append(results.slice, r.slice...)
+			}
+		case []interface{}:
+			results.slice = append(results.slice, r...)
+		default:
+			results.slice = append(results.slice, r)
+		}
+	}
+	return results
+}
+
+// Replace (in place) each element by the result of f applied to it
+// Return the slice pointer to allow method chaining.
+func (s *Slice) MapInPlace(f func(int, interface{}) interface{}) *Slice {
+	for i := 0; i < len(s.slice); i++ {
+		s.set(i, f(i, s.slice[i]))
+	}
+	return s
+}
+
+// Keep (in place) only the elements for which f returns true
+// Note: that's the opposite of RemoveFunc (which removes the matches) but done in
+// a single pass (O(n)) rather than removing the elements one at a time.
+// The function is given the element original index.
+// Return the slice pointer to allow method chaining.
+func (s *Slice) FilterInPlace(f func(int, interface{}) (keep bool)) *Slice {
+	if s.obs != nil {
+		s.filterObserved(f)
+		return s
+	}
+	kept := 0
+	for i, e := range s.slice {
+		if f(i, e) {
+			s.slice[kept] = e
+			kept++
+		}
+	}
+	// don't hold on to the removed elements
+	for i := kept; i < len(s.slice); i++ {
+		s.slice[i] = nil
+	}
+	s.slice = s.slice[:kept]
+	return s
+}
+
+// FilterInPlace of an observed slice: each run of consecutive elements to drop
+// is submitted up front as one SliceRemoved (a vetoed run is kept), then the
+// slice is compacted in a single pass and the removals are reported.
+func (s *Slice) filterObserved(f func(int, interface{}) (keep bool)) {
+	keep := make([]bool, len(s.slice))
+	for i, e := range s.slice {
+		keep[i] = f(i, e)
+	}
+	events := []SliceEvent{}
+	removed := 0 // Shifts the index of the later runs
+	for start := 0; start < len(keep); {
+		if keep[start] {
+			start++
+			continue
+		}
+		end := start + 1
+		for end < len(keep) && !keep[end] {
+			end++
+		}
+		event := SliceRemoved{Index: start - removed, Elems: append([]interface{}{}, s.slice[start:end]...)}
+		if s.obs.allow(event) {
+			events = append(events, event)
+			removed += end - start
+		} else {
+			for i := start; i < end; i++ {
+				keep[i] = true
+			}
+		}
+		start = end
+	}
+	kept := 0
+	for i, e := range s.slice {
+		if keep[i] {
+			s.slice[kept] = e
+			kept++
+		}
+	}
+	for i := kept; i < len(s.slice); i++ {
+		s.slice[i] = nil // don't hold on to the removed elements
+	}
+	s.slice = s.slice[:kept]
+	for _, event := range events {
+		s.obs.notify(event)
+	}
+}
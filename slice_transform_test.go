@@ -0,0 +1,68 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestSliceMap(t *testing.T) {
+
+	convey.Convey("Map", t, func() {
+		s := testSlice()
+		doubled := s.Map(func(e interface{}) interface{} { return e.(int) * 2 })
+		convey.So(doubled.Join(","), convey.ShouldEqual, "2,4,6,14,18,30")
+		convey.So(s.Join(","), convey.ShouldEqual, "1,2,3,7,9,15")
+		convey.So(NewSlice().Map(func(e interface{}) interface{} { return e }).IsEmpty(), convey.ShouldBeTrue)
+		indexed := s.MapIndexed(func(i int, e interface{}) interface{} { return i + e.(int) })
+		convey.So(indexed.Join(","), convey.ShouldEqual, "1,3,5,10,13,20")
+		negated := s.ReadOnly().Map(func(e interface{}) interface{} { return -e.(int) })
+		convey.So(negated.Join(","), convey.ShouldEqual, "-1,-2,-3,-7,-9,-15")
+	})
+
+	convey.Convey("FlatMap", t, func() {
+		s := NewSlice().AppendAll(1, 2, 3)
+		flat := s.FlatMap(func(e interface{}) interface{} {
+			switch e.(int) {
+			case 1:
+				return NewSlice().AppendAll("a", "b")
+			case 2:
+				return nil
+			}
+			return []interface{}{"c", "d"}
+		})
+		convey.So(flat.Join(","), convey.ShouldEqual, "a,b,c,d")
+		flat = s.FlatMap(func(e interface{}) interface{} { return e })
+		convey.So(flat.Join(","), convey.ShouldEqual, "1,2,3")
+		flat = s.FlatMap(func(e interface{}) interface{} {
+			var none *Slice
+			if e.(int) == 2 {
+				return none
+			}
+			return []interface{}{e}
+		})
+		convey.So(flat.Join(","), convey.ShouldEqual, "1,3")
+	})
+
+	convey.Convey("MapInPlace", t, func() {
+		s := testSlice()
+		convey.So(s.MapInPlace(func(i int, e interface{}) interface{} { return e.(int) + 1 }), convey.ShouldEqual, s)
+		convey.So(s.Join(","), convey.ShouldEqual, "2,3,4,8,10,16")
+	})
+
+	convey.Convey("FilterInPlace", t, func() {
+		s := testSlice()
+		indexes := []int{}
+		s.FilterInPlace(func(i int, e interface{}) bool {
+			indexes = append(indexes, i)
+			return e.(int)%3 == 0
+		})
+		convey.So(s.Join(","), convey.ShouldEqual, "3,9,15")
+		convey.So(indexes, convey.ShouldResemble, []int{0, 1, 2, 3, 4, 5})
+		raw := *s.Slice()
+		convey.So(raw[:cap(raw)][3], convey.ShouldBeNil)
+		s.FilterInPlace(func(i int, e interface{}) bool { return false })
+		convey.So(s.IsEmpty(), convey.ShouldBeTrue)
+	})
+}
@@ -0,0 +1,42 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Run f on a copy of the slice, the changes it makes are applied to the slice
+// only if it returns no error (all or nothing)
+// A panic in f (ie: invalid index) is recovered and returned as an error.
+// If the slice is observed the committed changes are reported as the edits of
+// Diff(slice, tx) (See Apply()), if one is vetoed nothing is committed and the
+// veto error is returned.
+func (s *Slice) Transaction(f func(tx *Slice) error) error {
+	tx := s.Clone()
+	if err := runTx(tx, f); err != nil {
+		return err
+	}
+	return s.commit(tx)
+}
+
+// Apply the changes of a successful transaction
+func (s *Slice) commit(tx *Slice) error {
+	if s.obs == nil {
+		s.slice = tx.slice
+		return nil
+	}
+	// Can only fail if an edit is vetoed, the patch was just computed from the slice
+	return s.Apply(Diff(s, tx))
+}
+
+// Run the transaction function, turning panics into errors
+func runTx(tx *Slice, f func(tx *Slice) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New(fmt.Sprintf("Transaction failed: %v", r))
+		}
+	}()
+	return f(tx)
+}
@@ -0,0 +1,104 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"errors"
+	"github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestSliceTransaction(t *testing.T) {
+
+	convey.Convey("Commit", t, func() {
+		s := testSlice()
+		err := s.Transaction(func(tx *Slice) error {
+			tx.RemoveAt(0).Append(20)
+			tx.Set(0, 5)
+			return nil
+		})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(s.Join(","), convey.ShouldEqual, "5,3,7,9,15,20")
+	})
+
+	convey.Convey("Rollback", t, func() {
+		s := testSlice()
+		errBoom := errors.New("boom")
+		err := s.Transaction(func(tx *Slice) error {
+			tx.Clear().Append(1)
+			return errBoom
+		})
+		convey.So(err, convey.ShouldEqual, errBoom)
+		convey.So(s.Join(","), convey.ShouldEqual, "1,2,3,7,9,15")
+		err = s.Transaction(func(tx *Slice) error {
+			tx.Reverse()
+			tx.Insert(99, 0) // invalid index -> panic
+			return nil
+		})
+		convey.So(err.Error(), convey.ShouldEqual, "Transaction failed: Invalid slice index: 99")
+		convey.So(s.Join(","), convey.ShouldEqual, "1,2,3,7,9,15")
+	})
+
+	convey.Convey("Observed", t, func() {
+		s := testSlice()
+		h := NewHistory(s, 0)
+		events := 0
+		s.Subscribe(func(e SliceEvent) { events++ })
+		s.Transaction(func(tx *Slice) error {
+			convey.So(tx.LastVeto(), convey.ShouldBeNil)
+			tx.RemoveAt(1).Append(4)
+			return nil
+		})
+		convey.So(s.Join(","), convey.ShouldEqual, "1,3,7,9,15,4")
+		convey.So(events, convey.ShouldEqual, 2)
+		for h.Undo() {
+		}
+		convey.So(s.Join(","), convey.ShouldEqual, "1,2,3,7,9,15")
+		s.Transaction(func(tx *Slice) error {
+			tx.Append(5)
+			return errors.New("no")
+		})
+		convey.So(events, convey.ShouldEqual, 4)
+		// vetoed commit
+		errNo := errors.New("no")
+		s.OnBeforeChange(func(e SliceEvent) error { return errNo })
+		err := s.Transaction(func(tx *Slice) error {
+			tx.Append(5)
+			return nil
+		})
+		convey.So(err, convey.ShouldEqual, errNo)
+		convey.So(s.Join(","), convey.ShouldEqual, "1,2,3,7,9,15")
+	})
+
+	convey.Convey("Vetoed after some edits", t, func() {
+		s := NewSlice().AppendAll(1, 2, 3)
+		errNo := errors.New("no")
+		changes := 0
+		s.OnBeforeChange(func(e SliceEvent) error {
+			if changes++; changes == 2 {
+				return errNo
+			}
+			return nil
+		})
+		events := 0
+		s.Subscribe(func(e SliceEvent) { events++ })
+		err := s.Transaction(func(tx *Slice) error {
+			tx.Set(0, 10).Set(2, 30)
+			return nil
+		})
+		convey.So(err, convey.ShouldEqual, errNo)
+		convey.So(changes, convey.ShouldEqual, 2)
+		convey.So(events, convey.ShouldEqual, 0)
+		convey.So(s.Join(","), convey.ShouldEqual, "1,2,3")
+		// SyncSlice commits the same way
+		changes = 0
+		ss := NewSyncSlice(s)
+		err = ss.Transaction(func(tx *Slice) error {
+			tx.Set(0, 10).Set(2, 30)
+			return nil
+		})
+		convey.So(err, convey.ShouldEqual, errNo)
+		convey.So(ss.String(), convey.ShouldEqual, s.String())
+		convey.So(s.Join(","), convey.ShouldEqual, "1,2,3")
+	})
+}
@@ -0,0 +1,105 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"fmt"
+)
+
+// Returns a new Slice of *Pair made of the elements of this slice and other at the same index
+// The result is as long as the shortest of the two slices.
+func (s *Slice) Zip(other *Slice) *Slice {
+	return s.ZipWith(other, func(a, b interface{}) interface{} {
+		return NewPair(a, b)
+	})
+}
+
+// Returns a new Slice of the results of f applied to the elements of this slice
+// and other at the same index.
+// The result is as long as the shortest of the two slices.
+func (s *Slice) ZipWith(other *Slice, f func(a, b interface{}) interface{}) *Slice {
+	size := len(s.slice)
+	if len(other.slice) < size {
+		size = len(other.slice)
+	}
+	results := NewSlice()
+	results.slice = make([]interface{}, size)
+	for i := 0; i != size; i++ {
+		results.slice[i] = f(s.slice[i], other.slice[i])
+	}
+	return results
+}
+
+// Same as Zip() but the result is as long as the longest of the two slices,
+// the missing elements of the shortest one are replaced by fill.
+func (s *Slice) ZipLongest(other *Slice, fill interface{}) *Slice {
+	size := len(s.slice)
+	if len(other.slice) > size {
+		size = len(other.slice)
+	}
+	results := NewSlice()
+	results.slice = make([]interface{}, size)
+	for i := 0; i != size; i++ {
+		a, b := fill, fill
+		if i < len(s.slice) {
+			a = s.slice[i]
+		}
+		if i < len(other.slice) {
+			b = other.slice[i]
+		}
+		results.slice[i] = NewPair(a, b)
+	}
+	return results
+}
+
+// Split a Slice of *Pair (as created by Zip) back into two new slices
+// made of the first and second values of the pairs.
+// Will panic if an element is not a *Pair
+func (s *Slice) Unzip() (firsts, seconds *Slice) {
+	firsts, seconds = NewSlice(), NewSlice()
+	firsts.slice = make([]interface{}, len(s.slice))
+	seconds.slice = make([]interface{}, len(s.slice))
+	for i, e := range s.slice {
+		p, ok := e.(*Pair)
+		if !ok {
+			panic(fmt.Sprintf("Can't unzip element %d, not a *Pair: %T", i, e))
+		}
+		firsts.slice[i], seconds.slice[i] = p.first, p.second
+	}
+	return firsts, seconds
+}
+
+// Cartesian product of this slice and the others
+// Returns a new Slice of tuples (*Slice of len(others)+1 elements), in order,
+// ie: [1 2].Product([a b]) -> [[1 a] [1 b] [2 a] [2 b]]
+// The result is empty if any of the slices is.
+func (s *Slice) Product(others ...*Slice) *Slice {
+	slices := append([]*Slice{s}, others...)
+	results := NewSlice()
+	for _, slice := range slices {
+		if slice.IsEmpty() {
+			return results
+		}
+	}
+	// current index in each slice, the last one moving the fastest
+	indexes := make([]int, len(slices))
+	for {
+		tuple := NewSlice()
+		tuple.slice = make([]interface{}, len(slices))
+		for i, slice := range slices {
+			tuple.slice[i] = slice.slice[indexes[i]]
+		}
+		results.slice = append(results.slice, tuple)
+		i := len(slices) - 1
+		for ; i >= 0; i-- {
+			indexes[i]++
+			if indexes[i] < len(slices[i].slice) {
+				break
+			}
+			indexes[i] = 0
+		}
+		if i < 0 {
+			return results
+		}
+	}
+}
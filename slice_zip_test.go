@@ -0,0 +1,62 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestSliceZip(t *testing.T) {
+	var name string
+	var age int
+
+	convey.Convey("Zip", t, func() {
+		names := NewSlice().AppendAll("joe", "al", "ed")
+		ages := NewSlice().AppendAll(42, 27)
+		zipped := names.Zip(ages)
+		convey.So(zipped.Join(" "), convey.ShouldEqual, "(joe, 42) (al, 27)")
+		var p *Pair
+		zipped.Get(1, &p)
+		p.First(&name)
+		p.Second(&age)
+		convey.So(name, convey.ShouldEqual, "al")
+		convey.So(age, convey.ShouldEqual, 27)
+		convey.So(names.Zip(NewSlice()).IsEmpty(), convey.ShouldBeTrue)
+	})
+
+	convey.Convey("ZipWith", t, func() {
+		a := NewSlice().AppendAll(1, 2, 3)
+		b := NewSlice().AppendAll(10, 20, 30, 40)
+		sums := a.ZipWith(b, func(x, y interface{}) interface{} { return x.(int) + y.(int) })
+		convey.So(sums.Join(","), convey.ShouldEqual, "11,22,33")
+	})
+
+	convey.Convey("ZipLongest", t, func() {
+		a := NewSlice().AppendAll(1, 2)
+		b := NewSlice().AppendAll("a", "b", "c")
+		convey.So(a.ZipLongest(b, nil).Join(" "), convey.ShouldEqual, "(1, a) (2, b) (<nil>, c)")
+		convey.So(b.ZipLongest(a, 0).Join(" "), convey.ShouldEqual, "(a, 1) (b, 2) (c, 0)")
+	})
+
+	convey.Convey("Unzip", t, func() {
+		a := NewSlice().AppendAll(1, 2)
+		b := NewSlice().AppendAll("a", "b")
+		firsts, seconds := a.Zip(b).Unzip()
+		convey.So(firsts.Join(","), convey.ShouldEqual, "1,2")
+		convey.So(seconds.Join(","), convey.ShouldEqual, "a,b")
+		convey.So(func() { a.Unzip() }, convey.ShouldPanic)
+	})
+
+	convey.Convey("Product", t, func() {
+		a := NewSlice().AppendAll(1, 2)
+		b := NewSlice().AppendAll("a", "b")
+		c := NewSlice().AppendAll(true)
+		product := a.Product(b, c)
+		convey.So(product.Len(), convey.ShouldEqual, 4)
+		convey.So(product.Map(func(e interface{}) interface{} { return e.(*Slice).Join("") }).Join(","),
+			convey.ShouldEqual, "1atrue,1btrue,2atrue,2btrue")
+		convey.So(a.Product().Join(","), convey.ShouldEqual, "Slice[1] [1],Slice[1] [2]")
+		convey.So(a.Product(b, NewSlice()).IsEmpty(), convey.ShouldBeTrue)
+	})
+}
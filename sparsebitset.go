@@ -0,0 +1,642 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"bytes"
+	"fmt"
+	"math/bits"
+	"sort"
+)
+
+// Compressed set of non negative integers, for sparse sets (roaring bitmap style)
+// Values are grouped in chunks of 65536 values (by their high bits), each
+// chunk being either a sorted array of values (when it holds few values) or
+// a bitmap (when it's dense), so memory usage is proportional to the number
+// of values rather than to the largest value (as with BitSet).
+// Methods taking indexes panic on negative indexes.
+// Ranges are inclusive (from and to), same as Slice ranges.
+type SparseBitSet struct {
+	// sorted by key
+	containers []*sparseContainer
+}
+
+// Chunks holding more values than this use a bitmap rather than an array
+const sparseArrayMax = 4096
+
+// Operations applied to a range of bits (See applyRange)
+type sparseOp int
+
+const (
+	sparseSet sparseOp = iota
+	sparseClear
+	sparseFlip
+)
+
+type sparseContainer struct {
+	// high bits (value >> 16) shared by all the values of this chunk
+	key int
+	// sorted low bits of the values, used unless bitmap is set
+	array []uint16
+	// 1024 words (65536 bits) bitmap, for dense chunks
+	bitmap []uint64
+	// number of bits set in bitmap
+	card int
+}
+
+// Initialize a new empty sparse bitset
+func NewSparseBitSet() *SparseBitSet {
+	return &SparseBitSet{}
+}
+
+// Initialize a new sparse bitset with the (int) values of the slice set
+func NewSparseBitSetFromSlice(s *Slice) *SparseBitSet {
+	b := NewSparseBitSet()
+	for _, e := range s.slice {
+		b.Set(e.(int))
+	}
+	return b
+}
+
+// Keep only the bits also set in other (in place intersection)
+// Return the bitset pointer to allow method chaining.
+func (b *SparseBitSet) And(other *SparseBitSet) *SparseBitSet {
+	b.combine(other, false, false, true)
+	return b
+}
+
+// Clear the bits also set in other (in place difference)
+// Return the bitset pointer to allow method chaining.
+func (b *SparseBitSet) AndNot(other *SparseBitSet) *SparseBitSet {
+	b.combine(other, true, false, false)
+	return b
+}
+
+// Number of bits set (population count)
+func (b *SparseBitSet) Cardinality() int {
+	count := 0
+	for _, c := range b.containers {
+		count += c.cardinality()
+	}
+	return count
+}
+
+// Clear (unset) the bit at index i
+// Return the bitset pointer to allow method chaining.
+func (b *SparseBitSet) Clear(i int) *SparseBitSet {
+	checkBitIndex(i)
+	idx, c := b.container(i >> 16)
+	if c != nil {
+		c.remove(uint16(i))
+		if c.cardinality() == 0 {
+			b.containers = append(b.containers[:idx], b.containers[idx+1:]...)
+		}
+	}
+	return b
+}
+
+// Clear all the bits
+// Return the bitset pointer to allow method chaining.
+func (b *SparseBitSet) ClearAll() *SparseBitSet {
+	b.containers = nil
+	return b
+}
+
+// Clear all the bits within the given range (from and to are both inclusive)
+// Return the bitset pointer to allow method chaining.
+func (b *SparseBitSet) ClearRange(from, to int) *SparseBitSet {
+	b.applyRange(from, to, sparseClear)
+	return b
+}
+
+// Create and return a clone of this bitset
+func (b *SparseBitSet) Clone() *SparseBitSet {
+	clone := NewSparseBitSet()
+	for _, c := range b.containers {
+		clone.containers = append(clone.containers, c.clone())
+	}
+	return clone
+}
+
+// Is the bit at the given (int) index set, false if elem is not a positive int
+// (the elements of a bitset being the indexes of its bits set, See Each())
+func (b *SparseBitSet) Contains(elem interface{}) bool {
+	i, ok := elem.(int)
+	return ok && i >= 0 && b.Test(i)
+}
+
+// Apply the function to all the bits set, in increasing order
+// The function is given the position (0 based) of the bit amongst the set bits
+// and the bit index (as an int).
+// If the function returns true (stop), iteration will stop
+func (b *SparseBitSet) Each(f func(int, interface{}) (stop bool)) {
+	pos := 0
+	for _, c := range b.containers {
+		for lo := c.next(0); lo >= 0; lo = c.next(lo + 1) {
+			if f(pos, c.key<<16|lo) {
+				return
+			}
+			pos++
+		}
+	}
+}
+
+// Is this bitset equal to another one (same bits set)
+func (b *SparseBitSet) Equals(other *SparseBitSet) bool {
+	if len(b.containers) != len(other.containers) {
+		return false
+	}
+	for i, c := range b.containers {
+		o := other.containers[i]
+		if c.key != o.key || c.cardinality() != o.cardinality() {
+			return false
+		}
+		cw, ow := c.words(), o.words()
+		for w := range cw {
+			if cw[w] != ow[w] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Flip (toggle) the bit at index i
+// Return the bitset pointer to allow method chaining.
+func (b *SparseBitSet) Flip(i int) *SparseBitSet {
+	if b.Test(i) {
+		return b.Clear(i)
+	}
+	return b.Set(i)
+}
+
+// Flip (toggle) all the bits within the given range (from and to are both inclusive)
+// Return the bitset pointer to allow method chaining.
+func (b *SparseBitSet) FlipRange(from, to int) *SparseBitSet {
+	b.applyRange(from, to, sparseFlip)
+	return b
+}
+
+// Is no bit set
+func (b *SparseBitSet) IsEmpty() bool {
+	return len(b.containers) == 0
+}
+
+// Number of bits set (same as Cardinality)
+func (b *SparseBitSet) Len() int {
+	return b.Cardinality()
+}
+
+// Index of the first clear bit at or after from
+// There is always one since the bitset is unbounded.
+func (b *SparseBitSet) NextClearBit(from int) int {
+	checkBitIndex(from)
+	key, lo := from>>16, from&0xFFFF
+	idx, c := b.container(key)
+	for c != nil {
+		if next := c.nextClear(lo); next >= 0 {
+			return key<<16 | next
+		}
+		// the rest of the chunk is full, look at the next one
+		key, lo = key+1, 0
+		idx++
+		c = nil
+		if idx < len(b.containers) && b.containers[idx].key == key {
+			c = b.containers[idx]
+		}
+	}
+	return key<<16 | lo
+}
+
+// Index of the first set bit at or after from
+// Returns -1 if there are none.
+func (b *SparseBitSet) NextSetBit(from int) int {
+	checkBitIndex(from)
+	key := from >> 16
+	idx := sort.Search(len(b.containers), func(i int) bool {
+		return b.containers[i].key >= key
+	})
+	for ; idx < len(b.containers); idx++ {
+		c := b.containers[idx]
+		lo := 0
+		if c.key == key {
+			lo = from & 0xFFFF
+		}
+		if next := c.next(lo); next >= 0 {
+			return c.key<<16 | next
+		}
+	}
+	return -1
+}
+
+// Set the bits that are set in other (in place union)
+// Return the bitset pointer to allow method chaining.
+func (b *SparseBitSet) Or(other *SparseBitSet) *SparseBitSet {
+	b.combine(other, true, true, true)
+	return b
+}
+
+// Unset all the bits, releasing the containers (impl Clearable)
+func (b *SparseBitSet) Reset() {
+	b.ClearAll()
+}
+
+// Set the bit at index i
+// Return the bitset pointer to allow method chaining.
+func (b *SparseBitSet) Set(i int) *SparseBitSet {
+	checkBitIndex(i)
+	idx, c := b.container(i >> 16)
+	if c == nil {
+		c = &sparseContainer{key: i >> 16}
+		b.containers = append(b.containers, nil)
+		copy(b.containers[idx+1:], b.containers[idx:])
+		b.containers[idx] = c
+	}
+	c.add(uint16(i))
+	return b
+}
+
+// Set all the bits within the given range (from and to are both inclusive)
+// Return the bitset pointer to allow method chaining.
+func (b *SparseBitSet) SetRange(from, to int) *SparseBitSet {
+	b.applyRange(from, to, sparseSet)
+	return b
+}
+
+// impl String interface
+func (b *SparseBitSet) String() string {
+	var buf bytes.Buffer
+	b.Each(func(pos int, i interface{}) bool {
+		if pos != 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString(fmt.Sprintf("%d", i))
+		return false
+	})
+	return fmt.Sprintf("SparseBitSet[%d] {%s}", b.Cardinality(), buf.String())
+}
+
+// Is the bit at index i set
+func (b *SparseBitSet) Test(i int) bool {
+	checkBitIndex(i)
+	_, c := b.container(i >> 16)
+	return c != nil && c.contains(uint16(i))
+}
+
+// Returns a new Slice of the (int) indexes of the bits set, in increasing order
+func (b *SparseBitSet) ToSlice() *Slice {
+	s := NewSlice()
+	b.Each(func(pos int, i interface{}) bool {
+		s.slice = append(s.slice, i)
+		return false
+	})
+	return s
+}
+
+// Only keep the bits set in either but not both bitsets (in place symmetric difference)
+// Return the bitset pointer to allow method chaining.
+func (b *SparseBitSet) Xor(other *SparseBitSet) *SparseBitSet {
+	b.combine(other, true, true, false)
+	return b
+}
+
+// Set, clear or flip (op) the bits within the given range, a chunk at a time
+// Full chunks are filled (or dropped) directly, partial ones updated a word at a time.
+func (b *SparseBitSet) applyRange(from, to int, op sparseOp) {
+	checkBitRange(from, to)
+	fromKey, toKey := from>>16, to>>16
+	idx, _ := b.container(fromKey)
+	result := append([]*sparseContainer{}, b.containers[:idx]...)
+	for key := fromKey; key <= toKey; key++ {
+		if op == sparseClear {
+			// only the existing chunks can change
+			if idx == len(b.containers) || b.containers[idx].key > toKey {
+				break
+			}
+			key = b.containers[idx].key
+		}
+		var c *sparseContainer
+		if idx < len(b.containers) && b.containers[idx].key == key {
+			c = b.containers[idx]
+			idx++
+		}
+		lo, hi := 0, 0xFFFF
+		if key == fromKey {
+			lo = from & 0xFFFF
+		}
+		if key == toKey {
+			hi = to & 0xFFFF
+		}
+		full := lo == 0 && hi == 0xFFFF
+		switch {
+		case full && op == sparseClear:
+			c = nil
+		case full && (op == sparseSet || c == nil):
+			c = fullSparseContainer(key)
+		case c == nil:
+			// flipping or setting bits of an empty chunk
+			c = &sparseContainer{key: key}
+			c.applyRange(lo, hi, sparseSet)
+		default:
+			c.applyRange(lo, hi, op)
+		}
+		if c != nil && c.cardinality() != 0 {
+			result = append(result, c)
+		}
+	}
+	b.containers = append(result, b.containers[idx:]...)
+}
+
+// Replace the content with the combination of this bitset and other
+// keeping the bits only in this one, only in other and/or in both.
+func (b *SparseBitSet) combine(other *SparseBitSet, onlyA, onlyB, both bool) {
+	var result []*sparseContainer
+	i, j := 0, 0
+	for i < len(b.containers) || j < len(other.containers) {
+		switch {
+		case j == len(other.containers) || (i < len(b.containers) && b.containers[i].key < other.containers[j].key):
+			if onlyA {
+				result = append(result, b.containers[i])
+			}
+			i++
+		case i == len(b.containers) || other.containers[j].key < b.containers[i].key:
+			if onlyB {
+				result = append(result, other.containers[j].clone())
+			}
+			j++
+		default:
+			c := b.containers[i].combine(other.containers[j], onlyA, onlyB, both)
+			if c.cardinality() != 0 {
+				result = append(result, c)
+			}
+			i++
+			j++
+		}
+	}
+	b.containers = result
+}
+
+// Find the container with the given key
+// Returns its index (or insertion index if none) and the container (nil if none)
+func (b *SparseBitSet) container(key int) (int, *sparseContainer) {
+	idx := sort.Search(len(b.containers), func(i int) bool {
+		return b.containers[i].key >= key
+	})
+	if idx < len(b.containers) && b.containers[idx].key == key {
+		return idx, b.containers[idx]
+	}
+	return idx, nil
+}
+
+// New container with all its 65536 values set
+func fullSparseContainer(key int) *sparseContainer {
+	c := &sparseContainer{key: key, bitmap: make([]uint64, 1024), card: 1 << 16}
+	for w := range c.bitmap {
+		c.bitmap[w] = ^uint64(0)
+	}
+	return c
+}
+
+// Add a value to the container
+func (c *sparseContainer) add(lo uint16) {
+	if c.bitmap != nil {
+		if c.bitmap[lo/64]&(1<<(lo%64)) == 0 {
+			c.bitmap[lo/64] |= 1 << (lo % 64)
+			c.card++
+		}
+		return
+	}
+	idx := c.search(lo)
+	if idx < len(c.array) && c.array[idx] == lo {
+		return
+	}
+	c.array = append(c.array, 0)
+	copy(c.array[idx+1:], c.array[idx:])
+	c.array[idx] = lo
+	if len(c.array) > sparseArrayMax {
+		c.bitmap = c.words()
+		c.card = len(c.array)
+		c.array = nil
+	}
+}
+
+// Number of values in the container
+func (c *sparseContainer) cardinality() int {
+	if c.bitmap != nil {
+		return c.card
+	}
+	return len(c.array)
+}
+
+func (c *sparseContainer) clone() *sparseContainer {
+	clone := &sparseContainer{key: c.key, card: c.card}
+	if c.bitmap != nil {
+		clone.bitmap = append([]uint64{}, c.bitmap...)
+	} else {
+		clone.array = append([]uint16{}, c.array...)
+	}
+	return clone
+}
+
+// New container combining this one and other (with the same key)
+// keeping the values only in this one, only in other and/or in both.
+func (c *sparseContainer) combine(other *sparseContainer, onlyA, onlyB, both bool) *sparseContainer {
+	result := &sparseContainer{key: c.key}
+	if c.bitmap == nil && other.bitmap == nil {
+		// merge the sorted arrays
+		a, b := c.array, other.array
+		i, j := 0, 0
+		for i < len(a) || j < len(b) {
+			switch {
+			case j == len(b) || (i < len(a) && a[i] < b[j]):
+				if onlyA {
+					result.array = append(result.array, a[i])
+				}
+				i++
+			case i == len(a) || b[j] < a[i]:
+				if onlyB {
+					result.array = append(result.array, b[j])
+				}
+				j++
+			default:
+				if both {
+					result.array = append(result.array, a[i])
+				}
+				i++
+				j++
+			}
+		}
+		if len(result.array) > sparseArrayMax {
+			result.bitmap = result.words()
+			result.card = len(result.array)
+			result.array = nil
+		}
+		return result
+	}
+	a, b := c.words(), other.words()
+	result.bitmap = make([]uint64, len(a))
+	for w := range a {
+		var word uint64
+		if onlyA {
+			word |= a[w] &^ b[w]
+		}
+		if onlyB {
+			word |= b[w] &^ a[w]
+		}
+		if both {
+			word |= a[w] & b[w]
+		}
+		result.bitmap[w] = word
+		result.card += bits.OnesCount64(word)
+	}
+	if result.card <= sparseArrayMax {
+		result.toArray()
+	}
+	return result
+}
+
+// Is the value in the container
+func (c *sparseContainer) contains(lo uint16) bool {
+	if c.bitmap != nil {
+		return c.bitmap[lo/64]&(1<<(lo%64)) != 0
+	}
+	idx := c.search(lo)
+	return idx < len(c.array) && c.array[idx] == lo
+}
+
+// Set, clear or flip (op) the values lo..hi (inclusive) of the container
+func (c *sparseContainer) applyRange(lo, hi int, op sparseOp) {
+	if c.bitmap == nil && op == sparseClear {
+		end := len(c.array)
+		if hi < 0xFFFF {
+			end = c.search(uint16(hi + 1))
+		}
+		c.array = append(c.array[:c.search(uint16(lo))], c.array[end:]...)
+		return
+	}
+	if c.bitmap == nil {
+		c.card = len(c.array)
+		c.bitmap = c.words()
+		c.array = nil
+	}
+	for w := lo / 64; w <= hi/64; w++ {
+		mask := ^uint64(0)
+		if w == lo/64 {
+			mask &= ^uint64(0) << uint(lo%64)
+		}
+		if w == hi/64 {
+			mask &= ^uint64(0) >> uint(63-hi%64)
+		}
+		old := c.bitmap[w]
+		switch op {
+		case sparseSet:
+			c.bitmap[w] |= mask
+		case sparseClear:
+			c.bitmap[w] &^= mask
+		case sparseFlip:
+			c.bitmap[w] ^= mask
+		}
+		c.card += bits.OnesCount64(c.bitmap[w]) - bits.OnesCount64(old)
+	}
+	if c.card <= sparseArrayMax {
+		c.toArray()
+	}
+}
+
+// Smallest value >= lo in the container, -1 if none
+func (c *sparseContainer) next(lo int) int {
+	if lo > 0xFFFF {
+		return -1
+	}
+	if c.bitmap == nil {
+		idx := c.search(uint16(lo))
+		if idx < len(c.array) {
+			return int(c.array[idx])
+		}
+		return -1
+	}
+	w := lo / 64
+	word := c.bitmap[w] & (^uint64(0) << uint(lo%64))
+	for {
+		if word != 0 {
+			return w*64 + bits.TrailingZeros64(word)
+		}
+		w++
+		if w == len(c.bitmap) {
+			return -1
+		}
+		word = c.bitmap[w]
+	}
+}
+
+// Smallest value >= lo not in the container, -1 if none
+func (c *sparseContainer) nextClear(lo int) int {
+	if c.bitmap == nil {
+		for idx := c.search(uint16(lo)); idx < len(c.array) && int(c.array[idx]) == lo; idx++ {
+			lo++
+		}
+		if lo > 0xFFFF {
+			return -1
+		}
+		return lo
+	}
+	w := lo / 64
+	word := ^c.bitmap[w] & (^uint64(0) << uint(lo%64))
+	for {
+		if word != 0 {
+			return w*64 + bits.TrailingZeros64(word)
+		}
+		w++
+		if w == len(c.bitmap) {
+			return -1
+		}
+		word = ^c.bitmap[w]
+	}
+}
+
+// Remove a value from the container
+func (c *sparseContainer) remove(lo uint16) {
+	if c.bitmap != nil {
+		if c.bitmap[lo/64]&(1<<(lo%64)) != 0 {
+			c.bitmap[lo/64] &^= 1 << (lo % 64)
+			c.card--
+			if c.card <= sparseArrayMax {
+				c.toArray()
+			}
+		}
+		return
+	}
+	idx := c.search(lo)
+	if idx < len(c.array) && c.array[idx] == lo {
+		c.array = append(c.array[:idx], c.array[idx+1:]...)
+	}
+}
+
+// Index of the first array value >= lo
+func (c *sparseContainer) search(lo uint16) int {
+	return sort.Search(len(c.array), func(i int) bool {
+		return c.array[i] >= lo
+	})
+}
+
+// Switch from bitmap to array representation
+func (c *sparseContainer) toArray() {
+	c.array = make([]uint16, 0, c.card)
+	for lo := c.next(0); lo >= 0; lo = c.next(lo + 1) {
+		c.array = append(c.array, uint16(lo))
+	}
+	c.bitmap = nil
+	c.card = 0
+}
+
+// Container values as a bitmap (a copy when the container is an array)
+func (c *sparseContainer) words() []uint64 {
+	if c.bitmap != nil {
+		return c.bitmap
+	}
+	words := make([]uint64, 1024)
+	for _, lo := range c.array {
+		words[lo/64] |= 1 << (lo % 64)
+	}
+	return words
+}
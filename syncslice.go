@@ -0,0 +1,89 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"sync"
+)
+
+// Slice safe for concurrent use
+// Readers (Read, Get, Len ...) can run concurrently, writers (Write, Transaction)
+// are run one at a time, a Transaction not blocking the readers until it's committed.
+type SyncSlice struct {
+	// guards the slice content
+	mu sync.RWMutex
+	// serializes the writers
+	writeMu sync.Mutex
+	s       *Slice
+}
+
+// Initialize a new SyncSlice wrapping s (a new empty slice if nil)
+// s should not be used directly after that.
+func NewSyncSlice(s *Slice) *SyncSlice {
+	if s == nil {
+		s = NewSlice()
+	}
+	return &SyncSlice{s: s}
+}
+
+// Set value of ptr to slice[idx] (See Slice.Get())
+func (ss *SyncSlice) Get(idx int, ptr interface{}) {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	ss.s.Get(idx, ptr)
+}
+
+// Length of the slice
+func (ss *SyncSlice) Len() int {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.Len()
+}
+
+// Run f with a read only view of the slice, no writer can change it meanwhile
+// The view should not be used after f returns.
+func (ss *SyncSlice) Read(f func(v *SliceView)) {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	f(ss.s.ReadOnly())
+}
+
+// Returns a copy of the slice
+func (ss *SyncSlice) Snapshot() *Slice {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.Clone()
+}
+
+// impl String interface
+func (ss *SyncSlice) String() string {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.String()
+}
+
+// Run f on a copy of the slice and commit its changes atomically if it returns
+// no error (See Slice.Transaction())
+// Readers are not blocked while f runs and see either none or all of the changes.
+func (ss *SyncSlice) Transaction(f func(tx *Slice) error) error {
+	ss.writeMu.Lock()
+	defer ss.writeMu.Unlock()
+	// No other writer can run, so the slice can be read without locking
+	tx := ss.s.Clone()
+	if err := runTx(tx, f); err != nil {
+		return err
+	}
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.s.commit(tx)
+}
+
+// Run f with the slice, readers and other writers are blocked meanwhile
+// The slice should not be used after f returns.
+func (ss *SyncSlice) Write(f func(s *Slice)) {
+	ss.writeMu.Lock()
+	defer ss.writeMu.Unlock()
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	f(ss.s)
+}
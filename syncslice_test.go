@@ -0,0 +1,75 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"errors"
+	"github.com/smartystreets/goconvey/convey"
+	"sync"
+	"testing"
+)
+
+func TestSyncSlice(t *testing.T) {
+	var val int
+
+	convey.Convey("Access", t, func() {
+		ss := NewSyncSlice(testSlice())
+		convey.So(ss.Len(), convey.ShouldEqual, 6)
+		ss.Get(-1, &val)
+		convey.So(val, convey.ShouldEqual, 15)
+		ss.Write(func(s *Slice) { s.Append(20) })
+		ss.Read(func(v *SliceView) {
+			convey.So(v.Join(","), convey.ShouldEqual, "1,2,3,7,9,15,20")
+		})
+		snapshot := ss.Snapshot()
+		snapshot.Clear()
+		convey.So(ss.Len(), convey.ShouldEqual, 7)
+		convey.So(ss.String(), convey.ShouldEqual, "Slice[7] [1 2 3 7 9 15 20]")
+		convey.So(NewSyncSlice(nil).Len(), convey.ShouldEqual, 0)
+	})
+
+	convey.Convey("Transactions", t, func() {
+		ss := NewSyncSlice(nil)
+		err := ss.Transaction(func(tx *Slice) error {
+			tx.AppendAll(1, 2)
+			return errors.New("rollback")
+		})
+		convey.So(err, convey.ShouldNotBeNil)
+		convey.So(ss.Len(), convey.ShouldEqual, 0)
+		// Readers always see complete transactions: pairs of elements summing to 0
+		var wg sync.WaitGroup
+		for w := 0; w != 4; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				for i := 1; i <= 50; i++ {
+					ss.Transaction(func(tx *Slice) error {
+						tx.Append(i)
+						tx.Append(-i)
+						if i%10 == 0 {
+							panic("skip")
+						}
+						return nil
+					})
+				}
+			}(w)
+		}
+		failures := 0
+		for r := 0; r != 200; r++ {
+			ss.Read(func(v *SliceView) {
+				if v.Len()%2 != 0 {
+					failures++
+				}
+				sum := v.Reduce(0, func(reduction interface{}, i int, e interface{}) interface{} {
+					return reduction.(int) + e.(int)
+				})
+				if sum != 0 {
+					failures++
+				}
+			})
+		}
+		wg.Wait()
+		convey.So(failures, convey.ShouldEqual, 0)
+		convey.So(ss.Len(), convey.ShouldEqual, 4*45*2)
+	})
+}
@@ -0,0 +1,308 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Trie (prefix tree) mapping string keys to "generic" values
+// It's a compacted trie (radix tree): chains of nodes with a single child are
+// merged into a single node, including after deletions.
+// Iteration is in (byte wise) lexicographic order of the keys.
+// []byte keys can be used as well (PutBytes, GetBytes, or converting with string())
+type Trie struct {
+	root *trieNode
+	// number of keys
+	size int
+}
+
+type trieNode struct {
+	// Label of the edge leading to this node
+	prefix   string
+	val      interface{}
+	hasVal   bool
+	children []*trieNode // sorted by the first byte of their prefix
+}
+
+// Initialize a new empty trie
+func NewTrie() *Trie {
+	return &Trie{root: &trieNode{}}
+}
+
+// Clear (empty) the trie
+// Return the trie pointer to allow method chaining.
+func (t *Trie) Clear() *Trie {
+	t.root = &trieNode{}
+	t.size = 0
+	return t
+}
+
+// Does the trie contain the given key
+func (t *Trie) Contains(key string) bool {
+	return t.find(key) != nil
+}
+
+// Apply the function to all the entries (in key order)
+// If the function returns true (stop), iteration will stop
+func (t *Trie) Each(f func(key string, val interface{}) (stop bool)) {
+	t.root.each("", f)
+}
+
+// Apply the function to all the entries whose key starts with prefix (in key order)
+// If the function returns true (stop), iteration will stop
+func (t *Trie) EachPrefix(prefix string, f func(key string, val interface{}) (stop bool)) {
+	if n, path := t.findPrefix(prefix); n != nil {
+		n.each(path[:len(path)-len(n.prefix)], f)
+	}
+}
+
+// Set value of ptr to the value associated with key
+// Return false (and leave ptr untouched) if the key is not in the trie
+func (t *Trie) Get(key string, ptr interface{}) (found bool) {
+	n := t.find(key)
+	if n == nil {
+		return false
+	}
+	PtrToVal(ptr).Set(reflect.ValueOf(n.val))
+	return true
+}
+
+// Same as Get() with a []byte key
+func (t *Trie) GetBytes(key []byte, ptr interface{}) (found bool) {
+	return t.Get(string(key), ptr)
+}
+
+// Is there at least one key starting with prefix
+func (t *Trie) HasPrefix(prefix string) bool {
+	n, _ := t.findPrefix(prefix)
+	// Thanks to compaction, any non root node leads to at least one key
+	return n != nil && (n != t.root || t.size > 0)
+}
+
+// Is this trie empty
+func (t *Trie) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Returns a new Slice of all the keys (in order)
+func (t *Trie) Keys() *Slice {
+	return t.KeysWithPrefix("")
+}
+
+// Returns a new Slice of all the keys starting with prefix (in order)
+func (t *Trie) KeysWithPrefix(prefix string) *Slice {
+	keys := NewSlice()
+	t.EachPrefix(prefix, func(key string, val interface{}) bool {
+		keys.slice = append(keys.slice, key)
+		return false
+	})
+	return keys
+}
+
+// Number of keys in the trie
+func (t *Trie) Len() int {
+	return t.size
+}
+
+// Find the longest key of the trie that is a prefix of str
+// ie: with keys "/api" and "/api/users", LongestPrefixOf("/api/users/7") is "/api/users"
+// found is false if no key is a prefix of str.
+func (t *Trie) LongestPrefixOf(str string) (key string, found bool) {
+	n := t.root
+	path := 0
+	for {
+		if n.hasVal {
+			key, found = str[:path], true
+		}
+		if path == len(str) {
+			return key, found
+		}
+		_, child := n.child(str[path])
+		if child == nil || commonPrefixLen(str[path:], child.prefix) != len(child.prefix) {
+			return key, found
+		}
+		path += len(child.prefix)
+		n = child
+	}
+}
+
+// Set (add or replace) the value associated to key
+// Return the trie pointer to allow method chaining.
+func (t *Trie) Put(key string, val interface{}) *Trie {
+	n := t.root
+	for key != "" {
+		i, child := n.child(key[0])
+		if child == nil {
+			n.addChild(&trieNode{prefix: key})
+			_, n = n.child(key[0])
+			break
+		}
+		common := commonPrefixLen(key, child.prefix)
+		if common < len(child.prefix) {
+			// split the edge at the end of the common part
+			split := &trieNode{prefix: child.prefix[:common], children: []*trieNode{child}}
+			child.prefix = child.prefix[common:]
+			n.children[i] = split
+			child = split
+		}
+		key = key[common:]
+		n = child
+	}
+	if !n.hasVal {
+		t.size++
+	}
+	n.val, n.hasVal = val, true
+	return t
+}
+
+// Same as Put() with a []byte key
+// Return the trie pointer to allow method chaining.
+func (t *Trie) PutBytes(key []byte, val interface{}) *Trie {
+	return t.Put(string(key), val)
+}
+
+// Remove the given key (if present), compacting the nodes left behind
+// Return the trie pointer to allow method chaining.
+func (t *Trie) Remove(key string) *Trie {
+	// nodes from the root to the key node
+	path := []*trieNode{t.root}
+	n := t.root
+	for key != "" {
+		_, child := n.child(key[0])
+		if child == nil || commonPrefixLen(key, child.prefix) != len(child.prefix) {
+			return t
+		}
+		key = key[len(child.prefix):]
+		n = child
+		path = append(path, n)
+	}
+	if !n.hasVal {
+		return t
+	}
+	n.val, n.hasVal = nil, false
+	t.size--
+	if n == t.root {
+		return t
+	}
+	parent := path[len(path)-2]
+	switch len(n.children) {
+	case 0:
+		parent.removeChild(n)
+		if parent != t.root && !parent.hasVal && len(parent.children) == 1 {
+			parent.mergeChild()
+		}
+	case 1:
+		n.mergeChild()
+	}
+	return t
+}
+
+// Remove all the keys and their values (impl Clearable)
+func (t *Trie) Reset() {
+	t.Clear()
+}
+
+// impl String interface
+func (t *Trie) String() string {
+	var buf bytes.Buffer
+	t.Each(func(key string, val interface{}) bool {
+		if buf.Len() != 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString(fmt.Sprintf("%s:%v", key, val))
+		return false
+	})
+	return fmt.Sprintf("Trie[%d] [%s]", t.size, buf.String())
+}
+
+// Find the node holding the value of key, nil if none
+func (t *Trie) find(key string) *trieNode {
+	n, path := t.findPrefix(key)
+	if n == nil || len(path) != len(key) || !n.hasVal {
+		return nil
+	}
+	return n
+}
+
+// Find the topmost node whose path starts with prefix, nil if none
+// Also returns the full path (key) of that node.
+func (t *Trie) findPrefix(prefix string) (n *trieNode, path string) {
+	n = t.root
+	for len(path) < len(prefix) {
+		rest := prefix[len(path):]
+		_, child := n.child(rest[0])
+		if child == nil {
+			return nil, ""
+		}
+		common := commonPrefixLen(rest, child.prefix)
+		if common < len(rest) && common < len(child.prefix) {
+			return nil, ""
+		}
+		path += child.prefix
+		n = child
+	}
+	return n, path
+}
+
+// Add a child, keeping children sorted
+func (n *trieNode) addChild(child *trieNode) {
+	i := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].prefix[0] >= child.prefix[0]
+	})
+	n.children = append(n.children, nil)
+	copy(n.children[i+1:], n.children[i:])
+	n.children[i] = child
+}
+
+// Find the child whose prefix starts with b (-1, nil if none)
+func (n *trieNode) child(b byte) (int, *trieNode) {
+	i := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].prefix[0] >= b
+	})
+	if i < len(n.children) && n.children[i].prefix[0] == b {
+		return i, n.children[i]
+	}
+	return -1, nil
+}
+
+// Call f on the node and its children values, in order
+// path is the key leading to this node parent
+func (n *trieNode) each(path string, f func(key string, val interface{}) (stop bool)) (stop bool) {
+	path += n.prefix
+	if n.hasVal && f(path, n.val) {
+		return true
+	}
+	for _, child := range n.children {
+		if child.each(path, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge the single child of this (valueless) node into it
+func (n *trieNode) mergeChild() {
+	child := n.children[0]
+	n.prefix += child.prefix
+	n.val, n.hasVal = child.val, child.hasVal
+	n.children = child.children
+}
+
+// Remove the given child node
+func (n *trieNode) removeChild(child *trieNode) {
+	i, _ := n.child(child.prefix[0])
+	n.children = append(n.children[:i], n.children[i+1:]...)
+}
+
+// Length of the common prefix of a & b
+func commonPrefixLen(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,99 @@
+// History: Oct 18 26 agent Creation
+
+package gollections
+
+import (
+	"github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestTrie(t *testing.T) {
+	var result int
+
+	convey.Convey("Put & Get", t, func() {
+		tr := testTrie()
+		convey.So(tr.Len(), convey.ShouldEqual, 6)
+		convey.So(tr.Get("romanus", &result), convey.ShouldBeTrue)
+		convey.So(result, convey.ShouldEqual, 3)
+		convey.So(tr.Get("rom", &result), convey.ShouldBeFalse)
+		convey.So(tr.Get("romanusx", &result), convey.ShouldBeFalse)
+		convey.So(tr.Contains("rubens"), convey.ShouldBeTrue)
+		tr.Put("rubens", 44)
+		tr.Get("rubens", &result)
+		convey.So(result, convey.ShouldEqual, 44)
+		convey.So(tr.Len(), convey.ShouldEqual, 6)
+		tr.PutBytes([]byte{'r'}, 9)
+		convey.So(tr.GetBytes([]byte("r"), &result), convey.ShouldBeTrue)
+		convey.So(result, convey.ShouldEqual, 9)
+		tr.Put("", 0)
+		convey.So(tr.Contains(""), convey.ShouldBeTrue)
+		convey.So(tr.Len(), convey.ShouldEqual, 8)
+	})
+
+	convey.Convey("Ordered iteration", t, func() {
+		tr := testTrie()
+		convey.So(tr.Keys().Join(","), convey.ShouldEqual,
+			"romane,romanus,romulus,rubens,ruber,rubicon")
+		convey.So(tr.String(), convey.ShouldEqual,
+			"Trie[6] [romane:2 romanus:3 romulus:4 rubens:5 ruber:6 rubicon:7]")
+		keys := ""
+		tr.EachPrefix("rub", func(key string, val interface{}) bool {
+			keys += key + " "
+			return key == "ruber"
+		})
+		convey.So(keys, convey.ShouldEqual, "rubens ruber ")
+	})
+
+	convey.Convey("Prefixes", t, func() {
+		tr := testTrie()
+		convey.So(tr.HasPrefix("rom"), convey.ShouldBeTrue)
+		convey.So(tr.HasPrefix("romanu"), convey.ShouldBeTrue)
+		convey.So(tr.HasPrefix("romanusx"), convey.ShouldBeFalse)
+		convey.So(tr.HasPrefix("x"), convey.ShouldBeFalse)
+		convey.So(tr.HasPrefix(""), convey.ShouldBeTrue)
+		convey.So(NewTrie().HasPrefix(""), convey.ShouldBeFalse)
+		convey.So(tr.KeysWithPrefix("ro").Join(","), convey.ShouldEqual, "romane,romanus,romulus")
+		convey.So(tr.KeysWithPrefix("rube").Join(","), convey.ShouldEqual, "rubens,ruber")
+		convey.So(tr.KeysWithPrefix("ruby").IsEmpty(), convey.ShouldBeTrue)
+	})
+
+	convey.Convey("LongestPrefixOf", t, func() {
+		tr := NewTrie().Put("/api", 1).Put("/api/users", 2).Put("/static", 3)
+		key, found := tr.LongestPrefixOf("/api/users/7")
+		convey.So(found, convey.ShouldBeTrue)
+		convey.So(key, convey.ShouldEqual, "/api/users")
+		key, _ = tr.LongestPrefixOf("/api/user")
+		convey.So(key, convey.ShouldEqual, "/api")
+		key, _ = tr.LongestPrefixOf("/api")
+		convey.So(key, convey.ShouldEqual, "/api")
+		_, found = tr.LongestPrefixOf("/ap")
+		convey.So(found, convey.ShouldBeFalse)
+	})
+
+	convey.Convey("Remove & compaction", t, func() {
+		tr := testTrie()
+		tr.Remove("romulus").Remove("nothere").Remove("rom")
+		convey.So(tr.Len(), convey.ShouldEqual, 5)
+		convey.So(tr.Keys().Join(","), convey.ShouldEqual, "romane,romanus,rubens,ruber,rubicon")
+		// "rom" + "ulus" gone, so "rom" and "an" got merged back into "roman"
+		convey.So(tr.root.children[0].children[0].prefix, convey.ShouldEqual, "oman")
+		tr.Remove("romane").Remove("romanus")
+		convey.So(tr.root.children[0].prefix, convey.ShouldEqual, "rub")
+		tr.Remove("rubicon").Remove("ruber")
+		convey.So(tr.root.children[0].prefix, convey.ShouldEqual, "rubens")
+		convey.So(tr.Get("rubens", &result), convey.ShouldBeTrue)
+		convey.So(result, convey.ShouldEqual, 5)
+		tr.Remove("rubens")
+		convey.So(tr.IsEmpty(), convey.ShouldBeTrue)
+		convey.So(len(tr.root.children), convey.ShouldEqual, 0)
+		tr.Put("a", 1).Clear()
+		convey.So(tr.Len(), convey.ShouldEqual, 0)
+	})
+}
+
+// #################### TESTS DATA ############################################
+
+func testTrie() *Trie {
+	return NewTrie().Put("romane", 2).Put("romanus", 3).Put("romulus", 4).
+		Put("rubens", 5).Put("ruber", 6).Put("rubicon", 7)
+}